@@ -3,16 +3,48 @@
 package ccl_test_data
 
 import (
+	"fmt"
+
 	"github.com/catconflang/ccl-test-data/config"
 	"github.com/catconflang/ccl-test-data/loader"
 	"github.com/catconflang/ccl-test-data/types"
 )
 
-// Version of the package
+// Version of the package. The test corpus is versioned separately, see CorpusVersion.
 const Version = "v0.1.0"
 
-// NewLoader creates a test loader with sensible defaults
+// CorpusVersion returns the version of the embedded test corpus: the newest version
+// declared by its source files
+func CorpusVersion() (string, error) {
+	files, err := loader.FindTestFiles(Corpus, "source_tests", loader.DiscoveryOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	testLoader := loader.NewTestLoaderFS(Corpus, config.ImplementationConfig{})
+	var newest loader.Version
+	for _, file := range files {
+		suite, err := testLoader.LoadTestFile(file, loader.LoadOptions{Format: loader.FormatCompact})
+		if err != nil {
+			return "", fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		version, err := loader.ParseVersion(suite.Version)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", file, err)
+		}
+		if version.Compare(newest) > 0 {
+			newest = version
+		}
+	}
+	return newest.String(), nil
+}
+
+// NewLoader creates a test loader with sensible defaults. An empty testDataPath
+// loads the embedded Corpus instead of a checkout on disk.
 func NewLoader(testDataPath string, cfg config.ImplementationConfig) *loader.TestLoader {
+	if testDataPath == "" {
+		return loader.NewTestLoaderFS(Corpus, cfg)
+	}
 	return loader.NewTestLoader(testDataPath, cfg)
 }
 
@@ -0,0 +1,97 @@
+package ccl_test_data
+
+import (
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/loader"
+)
+
+func TestLoadCompatibleTests_EmbeddedCorpus(t *testing.T) {
+	cfg := config.ImplementationConfig{
+		SupportedFunctions: []config.CCLFunction{config.FunctionParse},
+	}
+
+	embedded, err := LoadCompatibleTests("", cfg)
+	if err != nil {
+		t.Fatalf("LoadCompatibleTests() error = %v", err)
+	}
+	if len(embedded) == 0 {
+		t.Fatal("LoadCompatibleTests() returned no tests from the embedded corpus")
+	}
+
+	onDisk, err := loader.NewTestLoader(".", cfg).LoadAllTests(loader.LoadOptions{
+		Format:     loader.FormatFlat,
+		FilterMode: loader.FilterCompatible,
+	})
+	if err != nil {
+		t.Fatalf("LoadAllTests() error = %v", err)
+	}
+	if len(embedded) != len(onDisk) {
+		t.Errorf("embedded corpus has %d compatible tests, checkout has %d", len(embedded), len(onDisk))
+	}
+}
+
+func TestCorpusVersion_PinExcludesLaterTests(t *testing.T) {
+	version, err := CorpusVersion()
+	if err != nil {
+		t.Fatalf("CorpusVersion() error = %v", err)
+	}
+	if _, err := loader.ParseVersion(version); err != nil {
+		t.Fatalf("CorpusVersion() = %q: %v", version, err)
+	}
+
+	testLoader := NewLoader("", config.ImplementationConfig{})
+	all, err := testLoader.LoadAllTests(loader.LoadOptions{Format: loader.FormatFlat, FilterMode: loader.FilterAll})
+	if err != nil {
+		t.Fatalf("LoadAllTests() error = %v", err)
+	}
+	current, err := testLoader.LoadAllTests(loader.LoadOptions{Format: loader.FormatFlat, FilterMode: loader.FilterAll, TargetVersion: version})
+	if err != nil {
+		t.Fatalf("LoadAllTests(%s) error = %v", version, err)
+	}
+	if len(current) != len(all) {
+		t.Errorf("pinning to the current corpus version %s loaded %d of %d tests", version, len(current), len(all))
+	}
+
+	baseline, err := testLoader.LoadAllTests(loader.LoadOptions{Format: loader.FormatFlat, FilterMode: loader.FilterAll, TargetVersion: "0.0.0"})
+	if err != nil {
+		t.Fatalf("LoadAllTests(0.0.0) error = %v", err)
+	}
+	changes, err := loader.ChangesSince(all, "0.0.0")
+	if err != nil {
+		t.Fatalf("ChangesSince() error = %v", err)
+	}
+	if len(baseline)+len(changes.Added) != len(all) {
+		t.Errorf("pinning to 0.0.0 loaded %d tests, want %d minus %d added later", len(baseline), len(all), len(changes.Added))
+	}
+}
+
+func TestGetTestStats_CompatibleCounts(t *testing.T) {
+	cfg := config.ImplementationConfig{
+		SupportedFunctions: []config.CCLFunction{config.FunctionParse},
+	}
+
+	stats, err := GetTestStats("", cfg)
+	if err != nil {
+		t.Fatalf("GetTestStats() error = %v", err)
+	}
+	compatible, err := LoadCompatibleTests("", cfg)
+	if err != nil {
+		t.Fatalf("LoadCompatibleTests() error = %v", err)
+	}
+	if stats.CompatibleTests != len(compatible) {
+		t.Errorf("CompatibleTests = %d, want %d", stats.CompatibleTests, len(compatible))
+	}
+
+	excluded := 0
+	for _, tests := range stats.Excluded {
+		excluded += len(tests)
+	}
+	if stats.CompatibleTests+excluded != stats.TotalTests {
+		t.Errorf("%d compatible and %d excluded tests, want %d in total", stats.CompatibleTests, excluded, stats.TotalTests)
+	}
+	if len(stats.CompatibleByFunction) != 1 || stats.CompatibleByFunction["parse"] != stats.CompatibleTests {
+		t.Errorf("CompatibleByFunction = %v, want only parse", stats.CompatibleByFunction)
+	}
+}
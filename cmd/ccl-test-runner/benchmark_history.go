@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/catconflang/ccl-test-data/internal/benchmark"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/urfave/cli/v2"
+)
+
+// benchmarkHistoryAction shows the trend of every benchmark in the history and fails
+// when the latest run regressed against its rolling median
+func benchmarkHistoryAction(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "pretty" && format != "json" {
+		return fmt.Errorf("invalid --format value %q (expected pretty or json)", format)
+	}
+	window := ctx.Int("window")
+	if window < 1 {
+		return fmt.Errorf("invalid --window value %d (expected at least 1)", window)
+	}
+
+	historyFile := ctx.String("history")
+	runs, err := benchmark.LoadHistory(historyFile)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		styles.Warning("⚠️  No benchmark runs recorded in %s", historyFile)
+		return nil
+	}
+
+	trends := benchmark.AnalyzeHistory(runs, window, ctx.Float64("threshold"))
+	if format == "json" {
+		data, err := json.MarshalIndent(trends, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal benchmark trends: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		benchmark.PrintTrends(trends, window)
+	}
+
+	var regressed []string
+	for _, trend := range trends {
+		if len(trend.Points) > 1 && trend.Latest().Regression {
+			regressed = append(regressed, trend.Name)
+		}
+	}
+	if len(regressed) > 0 {
+		styles.Warning("⚠️  Latest run regressed: %v", regressed)
+		return fmt.Errorf("performance regression threshold exceeded")
+	}
+	if format == "pretty" {
+		styles.Success("✅ No regressions in the latest of %d run(s)", len(runs))
+	}
+	return nil
+}
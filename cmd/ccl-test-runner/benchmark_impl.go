@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/catconflang/ccl-test-data/internal/benchmark"
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/urfave/cli/v2"
+)
+
+// benchmarkImplAction benchmarks a CCL implementation on the benchmark corpus
+func benchmarkImplAction(ctx *cli.Context) error {
+	impl, err := implementation.Open(ctx.String("impl"))
+	if err != nil {
+		return err
+	}
+
+	corpus := benchmark.Corpus(ctx.Int("scale"))
+	styles.Status("🚀", fmt.Sprintf("Benchmarking %s on %d documents...", impl.Name(), len(corpus)))
+	for _, document := range corpus {
+		styles.InfoLite("   %s: %d KB, %s", document.Name, len(document.Text)/1024, document.Shape)
+	}
+
+	tracker := benchmark.NewTracker()
+	sampling := benchmark.SampleOptions{Warmup: ctx.Int("warmup"), Count: ctx.Int("count")}
+	skipped, err := tracker.MeasureImplementation(impl, corpus, sampling)
+	if err != nil {
+		return fmt.Errorf("benchmark failed: %w", err)
+	}
+	fmt.Println()
+
+	if err := recordBenchmarks(ctx, tracker); err != nil {
+		return err
+	}
+
+	for _, skip := range skipped {
+		styles.Warning("⚠️  Skipped %s: %s", skip.Name, skip.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/catconflang/ccl-test-data/internal/corpusdiff"
+	"github.com/urfave/cli/v2"
+)
+
+// corpusDiffAction prints a changelog of the tests that differ between two corpora
+func corpusDiffAction(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("expected OLD_DIR and NEW_DIR")
+	}
+	format := ctx.String("format")
+	if format != "markdown" && format != "json" {
+		return fmt.Errorf("invalid --format value %q (expected markdown or json)", format)
+	}
+
+	before, err := corpusdiff.Load(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("failed to load old corpus: %w", err)
+	}
+	after, err := corpusdiff.Load(ctx.Args().Get(1))
+	if err != nil {
+		return fmt.Errorf("failed to load new corpus: %w", err)
+	}
+
+	changelog := corpusdiff.Diff(before, after)
+	if format == "json" {
+		data, err := json.MarshalIndent(changelog, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal changelog: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Print(changelog.Markdown())
+	return nil
+}
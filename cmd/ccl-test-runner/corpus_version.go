@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// reportCorpusChanges lists the flat tests in inputDir that were added or changed after
+// the pinned corpus version, so implementations know what they have not validated
+func reportCorpusChanges(inputDir, pinned string) error {
+	files, err := loader.FindTestFiles(os.DirFS(inputDir), ".", loader.DiscoveryOptions{})
+	if err != nil {
+		return err
+	}
+
+	testLoader := loader.NewTestLoader(inputDir, config.ImplementationConfig{})
+	var tests []types.TestCase
+	for _, file := range files {
+		suite, err := testLoader.LoadTestFile(filepath.Join(inputDir, filepath.FromSlash(file)), loader.LoadOptions{
+			Format:     loader.FormatFlat,
+			FilterMode: loader.FilterAll,
+		})
+		if err != nil {
+			return err
+		}
+		tests = append(tests, suite.Tests...)
+	}
+
+	changes, err := loader.ChangesSince(tests, pinned)
+	if err != nil {
+		return err
+	}
+	if len(changes.Added) == 0 && len(changes.Changed) == 0 {
+		styles.InfoLite("No tests added or changed after corpus version %s", changes.Since)
+		return nil
+	}
+
+	if len(changes.Added) > 0 {
+		styles.Warning("%d tests added after corpus version %s were skipped:", len(changes.Added), changes.Since)
+		for _, test := range changes.Added {
+			styles.InfoLite("  %s (added in %s)", test.Name, test.AddedIn)
+		}
+	}
+	if len(changes.Changed) > 0 {
+		styles.Warning("%d tests changed after corpus version %s and may need revalidating:", len(changes.Changed), changes.Since)
+		for _, test := range changes.Changed {
+			styles.InfoLite("  %s (changed in %v)", test.Name, test.ChangedIn)
+		}
+	}
+	return nil
+}
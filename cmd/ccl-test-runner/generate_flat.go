@@ -8,6 +8,7 @@ import (
 
 	"github.com/catconflang/ccl-test-data/generator"
 	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/catconflang/ccl-test-data/loader"
 	"github.com/urfave/cli/v2"
 )
 
@@ -36,6 +37,11 @@ func generateFlatAction(ctx *cli.Context) error {
 	validate := ctx.Bool("validate")
 	verbose := ctx.Bool("verbose")
 
+	featureInference, ok := generator.ParseFeatureInferenceMode(ctx.String("infer-features"))
+	if !ok {
+		return fmt.Errorf("invalid --infer-features value %q (expected off, warn or add)", ctx.String("infer-features"))
+	}
+
 	styles.Status("⚡", fmt.Sprintf("Generating flat tests from %s to %s...", sourceDir, generatedDir))
 
 	// Resolve schemas directory relative to source directory if not absolute
@@ -56,6 +62,11 @@ func generateFlatAction(ctx *cli.Context) error {
 		SchemasDir:            schemasDir,
 		AutoGenerateConflicts: autoConflicts,
 		ValidateSourceTests:   validate,
+		FeatureInference:      featureInference,
+		Discovery: loader.DiscoveryOptions{
+			Include: ctx.StringSlice("include"),
+			Exclude: ctx.StringSlice("exclude"),
+		},
 	})
 
 	// Show metadata status
@@ -71,6 +82,13 @@ func generateFlatAction(ctx *cli.Context) error {
 		styles.Warning("Could not load behavior metadata from %s", schemasDir)
 	}
 
+	switch featureInference {
+	case generator.FeatureInferenceWarn:
+		styles.InfoLite("Warning about features inferred from test content")
+	case generator.FeatureInferenceAdd:
+		styles.InfoLite("Adding features inferred from test content")
+	}
+
 	// DELEGATION: Execute the flat generation using ccl-test-lib
 	// All conversion logic, validation, and file writing happens in the library
 	err := flatGen.GenerateAll()
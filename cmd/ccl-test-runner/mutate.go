@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/internal/mutation"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/urfave/cli/v2"
+)
+
+// mutateAction runs the generated Go tests against mutants of the mock implementation
+// and reports which mutants survived, grouped with the CCL functions they affect.
+func mutateAction(ctx *cli.Context) error {
+	format := ctx.String("format")
+
+	if ctx.Bool("list") {
+		styles.Info("🧬 Available mutations:")
+		for _, m := range mutation.Catalog() {
+			styles.InfoLite("  %-26s %s (%s)", m.ID, m.Description, strings.Join(m.Functions, ", "))
+		}
+		return nil
+	}
+
+	mutations, err := mutation.Select(ctx.StringSlice("only"))
+	if err != nil {
+		return err
+	}
+
+	runner := mutation.NewRunner(ctx.String("mock"), ctx.StringSlice("packages"))
+	if format != "json" {
+		styles.Status("🧬", fmt.Sprintf("Running %d mutants (packages: %s)", len(mutations), strings.Join(runner.Packages, " ")))
+		runner.Progress = func(result mutation.Result) {
+			switch result.Status {
+			case mutation.StatusKilled:
+				styles.InfoLite("  ✓ %-26s killed by %d tests (%v)", result.ID, len(result.KillingTests), result.Duration.Round(1e6))
+			case mutation.StatusSurvived:
+				styles.Warning("  ✗ %-26s survived (%v)", result.ID, result.Duration.Round(1e6))
+			default:
+				styles.Error("  ! %-26s %s: %s", result.ID, result.Status, firstLine(result.Detail))
+			}
+		}
+	}
+
+	report, err := runner.Run(mutations)
+	if err != nil {
+		return fmt.Errorf("mutation testing failed: %w", err)
+	}
+
+	if format == "json" {
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal mutation report: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	printMutationReport(report)
+	return nil
+}
+
+// printMutationReport shows the mutation score and the surviving mutants by function
+func printMutationReport(report *mutation.Report) {
+	fmt.Println()
+	if len(report.BaselineFailed) > 0 {
+		styles.InfoLite("Ignored %d tests already failing against the unmodified mock", len(report.BaselineFailed))
+	}
+	styles.Info("Mutation score: %.1f%%", report.Score())
+
+	survivors := report.Survivors()
+	if len(survivors) == 0 {
+		styles.Success("✅ All applied mutants were killed")
+		return
+	}
+
+	styles.Warning("⚠️  %d surviving mutants:", len(survivors))
+	byFunction := make(map[string][]string)
+	var functions []string
+	for _, survivor := range survivors {
+		styles.InfoLite("  %-26s %s", survivor.ID, survivor.Description)
+		for _, function := range survivor.Functions {
+			if _, seen := byFunction[function]; !seen {
+				functions = append(functions, function)
+			}
+			byFunction[function] = append(byFunction[function], survivor.ID)
+		}
+	}
+
+	fmt.Println()
+	styles.Info("Functions with undetected faults:")
+	for _, function := range functions {
+		styles.InfoLite("  %-20s %s", function, strings.Join(byFunction[function], ", "))
+	}
+}
+
+// firstLine returns the first line of a possibly multi-line message
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
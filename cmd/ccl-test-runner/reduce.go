@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/generator"
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/internal/reduce"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/urfave/cli/v2"
+)
+
+// reduceAction minimizes the input of a failing source test with delta debugging.
+//
+// A candidate input still fails when the implementation's result differs from the
+// reference implementation's result for the same validation. The original input must
+// fail against the test's recorded expectation, and must also fail in the reduction's
+// sense; when the implementation agrees with the reference there is nothing to reduce.
+// The minimized test takes its expectation from the reference implementation.
+func reduceAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected exactly one test name")
+	}
+	name := ctx.Args().First()
+	output := ctx.String("output")
+
+	impl, err := implementation.Open(ctx.String("impl"))
+	if err != nil {
+		return fmt.Errorf("failed to open implementation: %w", err)
+	}
+	reference, err := implementation.Open(ctx.String("reference"))
+	if err != nil {
+		return fmt.Errorf("failed to open reference implementation: %w", err)
+	}
+
+	test, function, err := findSourceTest(ctx.String("source"), name)
+	if err != nil {
+		return err
+	}
+	if ctx.String("function") != "" {
+		function = ctx.String("function")
+	}
+	validation, err := selectFailingValidation(impl, test, function)
+	if err != nil {
+		return err
+	}
+
+	// Only show status messages when stdout is not carrying the JSON output
+	status := output != ""
+	if status {
+		styles.Status("🔬", fmt.Sprintf("Reducing %s (%s) with %s against %s...", test.Name, validation.Function, impl.Name(), reference.Name()))
+	}
+
+	// The reference is known to be wrong on some inputs. Say so even when stdout carries
+	// the JSON output, since the reduction then chases the wrong disagreement.
+	original, err := reference.Run(validation.Function, test.Inputs, validation.Args)
+	if err != nil {
+		return fmt.Errorf("failed to run reference %s: %w", reference.Name(), err)
+	}
+	if !original.Matches(validation.Expect) {
+		message := fmt.Sprintf("Reference %s does not match the recorded expectation for %s:\n  reference: %s\n  expected:  %s",
+			reference.Name(), test.Name, describeValue(original.Expect()), describeValue(validation.Expect))
+		if status {
+			styles.Warning("%s", message)
+		} else {
+			fmt.Fprintln(os.Stderr, message)
+		}
+	}
+
+	reducer := reduce.New(func(inputs []string) (bool, error) {
+		expected, err := reference.Run(validation.Function, inputs, validation.Args)
+		if err != nil {
+			return false, fmt.Errorf("reference %s failed: %w", reference.Name(), err)
+		}
+		actual, err := impl.Run(validation.Function, inputs, validation.Args)
+		if errors.Is(err, implementation.ErrUnsupported) {
+			return false, err
+		}
+		if err != nil {
+			// The implementation crashing on the candidate still counts as a failure
+			return true, nil
+		}
+		return !actual.Equal(expected), nil
+	})
+
+	inputs, err := reducer.Reduce(test.Inputs)
+	if errors.Is(err, reduce.ErrNotFailing) {
+		return fmt.Errorf("%s agrees with reference %s on the original input of %s; nothing to reduce", impl.Name(), reference.Name(), test.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("reduction failed: %w", err)
+	}
+
+	expected, err := reference.Run(validation.Function, inputs, validation.Args)
+	if err != nil {
+		return fmt.Errorf("failed to run reference %s: %w", reference.Name(), err)
+	}
+	expect, err := implementation.Normalize(expected.Expect())
+	if err != nil {
+		return err
+	}
+
+	minimized := loader.CompactTest{
+		Name:   ctx.String("name"),
+		Inputs: inputs,
+		Tests: []loader.CompactValidation{{
+			Function: validation.Function,
+			Expect:   expect,
+			Args:     validation.Args,
+		}},
+		Behaviors: test.Behaviors,
+		Variants:  test.Variants,
+		Spec:      test.Spec,
+		Conflicts: test.Conflicts,
+	}
+	if minimized.Name == "" {
+		minimized.Name = test.Name + "_minimized"
+	}
+
+	// Features found in the reduced input, plus the declared features that cannot be
+	// detected from content and so may still be needed
+	for _, feature := range test.Features {
+		if !slices.Contains(generator.InferableFeatures, config.CCLFeature(feature)) {
+			minimized.Features = append(minimized.Features, feature)
+		}
+	}
+	minimized.Features = append(minimized.Features, generator.AnalyzeFeatures(inputs, expect, nil).Inferred...)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(minimized); err != nil {
+		return fmt.Errorf("failed to encode minimized test: %w", err)
+	}
+
+	if output == "" {
+		fmt.Print(buf.String())
+		return nil
+	}
+	if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	styles.Success("✅ Reduced %d input lines to %d in %d attempts", countLines(test.Inputs), countLines(inputs), reducer.Attempts)
+	styles.InfoLite("Minimized test saved to %s", output)
+	return nil
+}
+
+// findSourceTest locates a source test by name. Flat test names (<source>_<function>)
+// are also accepted, in which case the function is returned as well.
+func findSourceTest(source, name string) (*loader.CompactTest, string, error) {
+	files, err := snapshotFiles(source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var file loader.CompactTestFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, "", fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+
+		for i := range file.Tests {
+			test := &file.Tests[i]
+			if test.Name == name {
+				return test, "", nil
+			}
+			for _, validation := range test.Tests {
+				if name == test.Name+"_"+validation.Function {
+					return test, validation.Function, nil
+				}
+			}
+		}
+	}
+
+	return nil, "", fmt.Errorf("test %s not found in %s", name, source)
+}
+
+// selectFailingValidation returns the validation to reduce: the one for function when
+// given, otherwise the first validation impl does not pass
+func selectFailingValidation(impl implementation.Implementation, test *loader.CompactTest, function string) (*loader.CompactValidation, error) {
+	for i := range test.Tests {
+		validation := &test.Tests[i]
+		if function != "" && validation.Function != function {
+			continue
+		}
+
+		actual, err := impl.Run(validation.Function, test.Inputs, validation.Args)
+		if errors.Is(err, implementation.ErrUnsupported) {
+			if function == "" {
+				continue
+			}
+			return nil, err
+		}
+		// A crash is as much a failure as a wrong result
+		if err != nil || !actual.Matches(validation.Expect) {
+			return validation, nil
+		}
+		if function != "" {
+			return nil, fmt.Errorf("%s passes %s/%s; nothing to reduce", impl.Name(), test.Name, function)
+		}
+	}
+
+	if function != "" {
+		return nil, fmt.Errorf("test %s has no %s validation", test.Name, function)
+	}
+	return nil, fmt.Errorf("%s has no failing validations in %s; nothing to reduce", impl.Name(), test.Name)
+}
+
+// describeValue renders an expected or actual value as compact JSON for messages
+func describeValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// countLines returns the total number of lines across inputs
+func countLines(inputs []string) int {
+	total := 0
+	for _, input := range inputs {
+		total += strings.Count(input, "\n") + 1
+	}
+	return total
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/catconflang/ccl-test-data/internal/site"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/urfave/cli/v2"
+)
+
+// siteAction renders the corpus as a static HTML site
+func siteAction(ctx *cli.Context) error {
+	corpus, err := site.Load(ctx.String("dir"))
+	if err != nil {
+		return err
+	}
+
+	output := ctx.String("output")
+	if err := corpus.Write(output); err != nil {
+		return err
+	}
+
+	styles.Success("✅ Rendered %d tests from %d source files", corpus.TotalTests, len(corpus.Pages))
+	styles.InfoLite("   Open %s", filepath.Join(output, "index.html"))
+	return nil
+}
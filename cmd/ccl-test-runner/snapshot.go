@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/internal/snapshot"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/urfave/cli/v2"
+)
+
+// snapshotAction fills in pending source test expectations using an implementation.
+//
+// Validations whose expect is missing or the pending placeholder are always filled in. With --update,
+// existing expectations that differ from the implementation are shown as a per-test diff
+// and only rewritten once confirmed interactively or with --yes.
+func snapshotAction(ctx *cli.Context) error {
+	source := ctx.String("source")
+	update := ctx.Bool("update")
+	assumeYes := ctx.Bool("yes")
+
+	impl, err := implementation.Open(ctx.String("impl"))
+	if err != nil {
+		return fmt.Errorf("failed to open implementation: %w", err)
+	}
+
+	files, err := snapshotFiles(source)
+	if err != nil {
+		return err
+	}
+
+	styles.Status("📸", fmt.Sprintf("Snapshotting %d source files with %s...", len(files), impl.Name()))
+
+	stdin := bufio.NewReader(os.Stdin)
+	var filled, updated, differing, unsupported int
+	for _, path := range files {
+		file, err := snapshot.LoadFile(path)
+		if err != nil {
+			return err
+		}
+
+		plan, err := file.Plan(impl)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot %s: %w", path, err)
+		}
+		unsupported += len(plan.Unsupported)
+
+		applied := 0
+		var review [][]*snapshot.Change
+		for _, change := range plan.Changes {
+			switch {
+			case change.Pending:
+				if err := change.Apply(); err != nil {
+					return fmt.Errorf("failed to record %s/%s: %w", change.Test, change.Function, err)
+				}
+				applied++
+				filled++
+			case update:
+				// Group consecutive changes so each test is reviewed once
+				if n := len(review); n > 0 && review[n-1][0].Test == change.Test {
+					review[n-1] = append(review[n-1], change)
+				} else {
+					review = append(review, []*snapshot.Change{change})
+				}
+			default:
+				differing++
+			}
+		}
+
+		for _, changes := range review {
+			printSnapshotDiff(path, changes)
+
+			if !assumeYes && !confirmSnapshot(stdin, changes[0].Test) {
+				styles.InfoLite("  Kept existing expectations for %s", changes[0].Test)
+				continue
+			}
+
+			for _, change := range changes {
+				if err := change.Apply(); err != nil {
+					return fmt.Errorf("failed to record %s/%s: %w", change.Test, change.Function, err)
+				}
+				applied++
+				updated++
+			}
+		}
+
+		if applied > 0 {
+			if err := file.Write(); err != nil {
+				return err
+			}
+			styles.InfoLite("✓ Updated %s", path)
+		}
+	}
+
+	styles.Success("✅ Filled %d pending expectations, updated %d existing expectations", filled, updated)
+	if differing > 0 {
+		styles.Warning("%d existing expectations differ from %s (rerun with --update to review them)", differing, impl.Name())
+	}
+	if unsupported > 0 {
+		styles.InfoLite("Skipped %d validations using functions %s does not support", unsupported, impl.Name())
+	}
+	return nil
+}
+
+// snapshotFiles resolves the --source flag to a list of source test files
+func snapshotFiles(source string) ([]string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source %s: %w", source, err)
+	}
+	if !info.IsDir() {
+		return []string{source}, nil
+	}
+
+	// Search tier subdirectories too, such as source_tests/experimental
+	files, err := loader.FindTestFiles(os.DirFS(source), ".", loader.DiscoveryOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no source test files found in %s", source)
+	}
+	for i, file := range files {
+		files[i] = filepath.Join(source, filepath.FromSlash(file))
+	}
+	return files, nil
+}
+
+// printSnapshotDiff shows the expectation changes proposed for a single test
+func printSnapshotDiff(path string, changes []*snapshot.Change) {
+	styles.Info("%s: %s", filepath.Base(path), changes[0].Test)
+	for _, change := range changes {
+		header := "  " + change.Function
+		if len(change.Args) > 0 {
+			header += " " + strings.Join(change.Args, ".")
+		}
+		if change.Error != "" {
+			header += fmt.Sprintf(" (error: %s)", change.Error)
+		}
+		styles.InfoLite("%s", header)
+		for _, line := range strings.Split(strings.TrimRight(change.Diff(), "\n"), "\n") {
+			fmt.Println("    " + line)
+		}
+	}
+}
+
+// confirmSnapshot asks whether the changes for test should be written
+func confirmSnapshot(stdin *bufio.Reader, test string) bool {
+	fmt.Printf("Update expectations for %s? [y/N] ", test)
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		// No input available: keep the existing expectations
+		fmt.Println()
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/internal/styles"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/urfave/cli/v2"
+)
+
+// traceAction shows the source test behind a flat test, or the flat tests behind a
+// source test, with the metadata the generator changed for each
+func traceAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected a TEST_NAME")
+	}
+	format := ctx.String("format")
+	if format != "pretty" && format != "json" {
+		return fmt.Errorf("invalid --format value %q (expected pretty or json)", format)
+	}
+
+	testLoader := loader.NewTestLoader(ctx.String("dir"), config.ImplementationConfig{})
+	trace, err := testLoader.Trace(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(trace, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal trace: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	styles.Info("🔎 Source test %s", trace.Source.Name)
+	styles.InfoLite("   %s", trace.SourceFile)
+	printTraceList("Behaviors", trace.Source.Behaviors)
+	printTraceList("Variants", trace.Source.Variants)
+	printTraceList("Conflicts", trace.Source.Conflicts.Tags())
+	fmt.Println()
+
+	if len(trace.Flat) == 0 {
+		styles.Warning("No flat tests were generated from %s; run 'just generate' to regenerate them", trace.Source.Name)
+		return nil
+	}
+
+	styles.Info("📄 %d flat test(s)", len(trace.Flat))
+	for _, flat := range trace.Flat {
+		marker := " "
+		if flat.Test.Name == ctx.Args().First() {
+			marker = "►"
+		}
+		fmt.Printf(" %s %s (%s)\n", marker, flat.Test.Name, flat.Test.Validation)
+		styles.InfoLite("     %s", flat.File)
+		printTraceList("  Behaviors", flat.Test.Behaviors)
+		printTraceList("  Dropped behaviors", flat.DroppedBehaviors)
+		printTraceList("  Conflicts", flat.Test.Conflicts.Tags())
+		printTraceList("  Added by generator", flat.AddedConflicts.Tags())
+	}
+	return nil
+}
+
+func printTraceList(label string, values []string) {
+	if len(values) > 0 {
+		fmt.Printf("   %s: %s\n", label, strings.Join(values, ", "))
+	}
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/loader"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// facet is a kind of test metadata the TUI can filter on
+type facet int
+
+const (
+	facetFunction facet = iota
+	facetFeature
+	facetBehavior
+	facetVariant
+	facetCount
+)
+
+var facetNames = [facetCount]string{"function", "feature", "behavior", "variant"}
+
+// Search and facet styles
+var (
+	searchStyle = lipgloss.NewStyle().
+			Foreground(primaryColor).
+			Bold(true)
+
+	facetHeaderStyle = lipgloss.NewStyle().
+				Foreground(warningColor).
+				Bold(true).
+				Margin(1, 0, 0, 0)
+
+	sourceFileStyle = lipgloss.NewStyle().
+			Foreground(subtleColor).
+			Italic(true)
+)
+
+// facetValues returns a test's metadata values for a facet. Tests without a
+// function list fall back to their validation.
+func facetValues(test TestCase, f facet) []string {
+	switch f {
+	case facetFunction:
+		if len(test.Functions) == 0 && test.Validation != "" {
+			return []string{test.Validation}
+		}
+		return test.Functions
+	case facetFeature:
+		return test.Features
+	case facetBehavior:
+		return test.Behaviors
+	case facetVariant:
+		return test.Variants
+	}
+	return nil
+}
+
+// testFilter narrows the visible tests by text search and metadata facets.
+// Values selected within one facet are alternatives; different facets must all match.
+type testFilter struct {
+	query    string
+	selected [facetCount]map[string]bool
+}
+
+// active reports whether the filter hides any tests
+func (f testFilter) active() bool {
+	if f.query != "" {
+		return true
+	}
+	for _, values := range f.selected {
+		if len(values) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether a test's name or inputs contain the query, ignoring
+// case, and whether it has one of the selected values of every facet in use
+func (f testFilter) matches(test TestCase) bool {
+	if f.query != "" && !containsFold(test.Name, f.query) {
+		found := false
+		for _, input := range test.Inputs {
+			if containsFold(input, f.query) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for fc, values := range f.selected {
+		if len(values) == 0 {
+			continue
+		}
+		found := false
+		for _, value := range facetValues(test, facet(fc)) {
+			if values[value] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// toggle selects or deselects a facet value
+func (f *testFilter) toggle(fc facet, value string) {
+	if f.selected[fc] == nil {
+		f.selected[fc] = make(map[string]bool)
+	}
+	if f.selected[fc][value] {
+		delete(f.selected[fc], value)
+	} else {
+		f.selected[fc][value] = true
+	}
+}
+
+// describe summarizes the selected facet values, e.g. "function: get_int, parse"
+func (f testFilter) describe() string {
+	var parts []string
+	for fc, values := range f.selected {
+		if len(values) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(values))
+		for value := range values {
+			names = append(names, value)
+		}
+		sort.Strings(names)
+		parts = append(parts, fmt.Sprintf("%s: %s", facetNames[fc], strings.Join(names, ", ")))
+	}
+	return strings.Join(parts, " • ")
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// facetOption is one selectable row of the facet picker
+type facetOption struct {
+	facet facet
+	value string
+	count int // Tests in the file or corpus with this value
+}
+
+// facetOptions lists every facet value present in tests, grouped by facet
+func facetOptions(tests []TestCase) []facetOption {
+	var options []facetOption
+	for fc := facet(0); fc < facetCount; fc++ {
+		counts := make(map[string]int)
+		for _, test := range tests {
+			for _, value := range facetValues(test, fc) {
+				counts[value]++
+			}
+		}
+		values := make([]string, 0, len(counts))
+		for value := range counts {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			options = append(options, facetOption{facet: fc, value: value, count: counts[value]})
+		}
+	}
+	return options
+}
+
+// applyFilters recomputes the visible tests from the search, facets and failure
+// filter, keeping the current test selected if it is still visible
+func (m *tuiModel) applyFilters() {
+	current := ""
+	if m.currentTest < len(m.tests) {
+		current = m.tests[m.currentTest].Name
+	}
+
+	m.tests = nil
+	for _, test := range m.allTests {
+		if m.filter.matches(test) && (!m.failuresOnly || m.failed(test)) {
+			m.tests = append(m.tests, test)
+		}
+	}
+
+	m.currentTest = 0
+	for i, test := range m.tests {
+		if test.Name == current {
+			m.currentTest = i
+			break
+		}
+	}
+	m.entryScroll = 0
+}
+
+// updateSearch handles keys while the search query is being typed
+func (m tuiModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		m.filter.query = ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.filter.query); len(runes) > 0 {
+			m.filter.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter.query += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	m.applyFilters()
+	return m, nil
+}
+
+// updateFacetPicker handles keys while the facet picker is open
+func (m tuiModel) updateFacetPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := facetOptions(m.allTests)
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "enter", "F":
+		m.picking = false
+	case "j", "down":
+		if m.pickerCursor < len(options)-1 {
+			m.pickerCursor++
+		}
+	case "k", "up":
+		if m.pickerCursor > 0 {
+			m.pickerCursor--
+		}
+	case "g":
+		m.pickerCursor = 0
+	case "G":
+		m.pickerCursor = max(len(options)-1, 0)
+	case " ", "x":
+		if m.pickerCursor < len(options) {
+			option := options[m.pickerCursor]
+			m.filter.toggle(option.facet, option.value)
+			m.applyFilters()
+		}
+	case "c":
+		m.filter.selected = [facetCount]map[string]bool{}
+		m.applyFilters()
+	}
+	return m, nil
+}
+
+// renderFacetPicker lists facet values with checkboxes, scrolled to keep the cursor visible
+func (m tuiModel) renderFacetPicker() string {
+	var content strings.Builder
+	content.WriteString(suiteHeaderStyle.Render("🏷️  Filter by Metadata") + "\n")
+
+	options := facetOptions(m.allTests)
+	visible := max(m.height-14, 5)
+	start := 0
+	if m.pickerCursor >= visible {
+		start = m.pickerCursor - visible + 1
+	}
+	end := min(start+visible, len(options))
+
+	for i := start; i < end; i++ {
+		option := options[i]
+		if i == start || options[i-1].facet != option.facet {
+			content.WriteString(facetHeaderStyle.Render(strings.ToUpper(facetNames[option.facet])) + "\n")
+		}
+		check := "[ ]"
+		if m.filter.selected[option.facet][option.value] {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %s (%d)", check, option.value, option.count)
+		if i == m.pickerCursor {
+			content.WriteString(selectedFileStyle.Render("► "+line) + "\n")
+		} else {
+			content.WriteString("  " + line + "\n")
+		}
+	}
+
+	content.WriteString("\n")
+	content.WriteString(summaryStyle.Render(fmt.Sprintf("%d of %d tests match", len(m.tests), len(m.allTests))) + "\n")
+	content.WriteString(suiteInfoStyle.Render("j/k: navigate • space: toggle • c: clear all • enter/esc: done"))
+	return content.String()
+}
+
+// renderFilterStatus shows the search query and selected facets, if any
+func (m tuiModel) renderFilterStatus() string {
+	if !m.searching && !m.filter.active() {
+		return ""
+	}
+
+	var parts []string
+	if m.searching || m.filter.query != "" {
+		query := "/" + m.filter.query
+		if m.searching {
+			query += "█"
+		}
+		parts = append(parts, searchStyle.Render(query))
+	}
+	if facets := m.filter.describe(); facets != "" {
+		parts = append(parts, tagStyle.Render(facets))
+	}
+	parts = append(parts, fmt.Sprintf("%d of %d tests match", len(m.tests), len(m.allTests)))
+	return "🔍 " + strings.Join(parts, " • ") + "\n"
+}
+
+// renderSourceFile names the file a test came from in corpus mode
+func (m tuiModel) renderSourceFile(test TestCase) string {
+	if !m.corpus {
+		return ""
+	}
+	return sourceFileStyle.Render(filepath.Base(m.testFiles[test.Name]))
+}
+
+// corpusRoot finds the directory holding generated_tests, starting from dir and
+// searching its parents. It falls back to the current directory.
+func corpusRoot(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "."
+	}
+	for {
+		if info, err := os.Stat(filepath.Join(abs, "generated_tests")); err == nil && info.IsDir() {
+			return abs
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "."
+		}
+		abs = parent
+	}
+}
+
+// loadCorpusCmd loads every flat test in the corpus, recording which file each came from
+func loadCorpusCmd(root string) tea.Cmd {
+	return func() tea.Msg {
+		impl := config.ImplementationConfig{
+			Name:    "test-reader",
+			Version: "1.0.0",
+		}
+		testLoader := loader.NewTestLoader(root, impl)
+		files, err := testLoader.LoadAllFiles(loader.LoadOptions{
+			Format:     loader.FormatFlat,
+			FilterMode: loader.FilterAll, // Load all tests for viewer
+		})
+		if err != nil {
+			return testLoadedMsg{err: fmt.Errorf("loading corpus: %w", err)}
+		}
+
+		msg := testLoadedMsg{
+			filename: filepath.Join(root, "generated_tests"),
+			files:    make(map[string]string),
+		}
+		for _, file := range files {
+			msg.suite.Tests = append(msg.suite.Tests, file.Tests...)
+			for _, test := range file.Tests {
+				msg.files[test.Name] = file.File
+			}
+		}
+		msg.suite.Suite = "Test Corpus"
+		msg.suite.Description = fmt.Sprintf("%d tests in %d files", len(msg.suite.Tests), len(files))
+		return msg
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTestFilter(t *testing.T) {
+	tests := []TestCase{
+		{Name: "basic_parse", Inputs: []string{"Key = Value"}, Functions: []string{"parse"}, Features: []string{"whitespace"}},
+		{Name: "comment_filter", Inputs: []string{"/= note"}, Functions: []string{"filter"}, Features: []string{"comments"}},
+		{Name: "port_get_int", Inputs: []string{"port = 8080"}, Functions: []string{"get_int"}, Behaviors: []string{"boolean_strict"}},
+		{Name: "legacy_print", Inputs: []string{"a = 1"}, Validation: "print"},
+	}
+
+	type selection struct {
+		facet facet
+		value string
+	}
+	cases := []struct {
+		name     string
+		query    string
+		selected []selection
+		want     []string
+		describe string
+	}{
+		{name: "no filter", want: []string{"basic_parse", "comment_filter", "port_get_int", "legacy_print"}},
+		{name: "name ignoring case", query: "PORT_GET", want: []string{"port_get_int"}},
+		{name: "input ignoring case", query: "key = value", want: []string{"basic_parse"}},
+		{
+			name:     "alternatives within a facet",
+			selected: []selection{{facetFunction, "parse"}, {facetFunction, "get_int"}},
+			want:     []string{"basic_parse", "port_get_int"},
+			describe: "function: get_int, parse",
+		},
+		{
+			name:     "every facet must match",
+			selected: []selection{{facetFunction, "parse"}, {facetFunction, "filter"}, {facetFeature, "comments"}},
+			want:     []string{"comment_filter"},
+			describe: "function: filter, parse • feature: comments",
+		},
+		{
+			name:     "query and facet",
+			query:    "note",
+			selected: []selection{{facetFunction, "parse"}},
+			describe: "function: parse",
+		},
+		{
+			name:     "validation without functions",
+			selected: []selection{{facetFunction, "print"}},
+			want:     []string{"legacy_print"},
+			describe: "function: print",
+		},
+		{
+			name:     "toggled twice",
+			selected: []selection{{facetBehavior, "boolean_strict"}, {facetBehavior, "boolean_strict"}},
+			want:     []string{"basic_parse", "comment_filter", "port_get_int", "legacy_print"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := testFilter{query: tt.query}
+			for _, s := range tt.selected {
+				filter.toggle(s.facet, s.value)
+			}
+
+			var got []string
+			for _, test := range tests {
+				if filter.matches(test) {
+					got = append(got, test.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matches() kept %v, want %v", got, tt.want)
+			}
+			if describe := filter.describe(); describe != tt.describe {
+				t.Errorf("describe() = %q, want %q", describe, tt.describe)
+			}
+			if active := tt.query != "" || tt.describe != ""; filter.active() != active {
+				t.Errorf("active() = %v, want %v", filter.active(), active)
+			}
+		})
+	}
+
+	// Options are grouped by facet and sorted, with the validation standing in for
+	// missing functions
+	want := []facetOption{
+		{facetFunction, "filter", 1},
+		{facetFunction, "get_int", 1},
+		{facetFunction, "parse", 1},
+		{facetFunction, "print", 1},
+		{facetFeature, "comments", 1},
+		{facetFeature, "whitespace", 1},
+		{facetBehavior, "boolean_strict", 1},
+	}
+	if got := facetOptions(tests); !reflect.DeepEqual(got, want) {
+		t.Errorf("facetOptions() = %+v, want %+v", got, want)
+	}
+}
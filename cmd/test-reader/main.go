@@ -1,14 +1,18 @@
 // CCL Test Reader - Interactive viewer for CCL test files
 //
-// PREFERRED: Use with generated format files (generated_tests/) for full functionality
-// DEPRECATED: Source format files (source_tests/) have limited display support
-//
-// The test-reader displays CCL test cases with their input, expected output,
-// and metadata. It supports both static CLI output and interactive TUI modes.
+// The test-reader displays CCL test cases from source (source_tests/) and
+// generated flat (generated_tests/) files with their inputs, every expected
+// validation and metadata. It supports both static CLI output and interactive TUI modes.
+// The TUI can overlay implementation results, read from a conformance run's
+// results file (--results) or produced by running an implementation (--impl).
+// Tests can be searched and filtered by metadata, within one file or across
+// the whole flat corpus (--corpus). The playground (--playground, or p in the
+// TUI) shows the mock's output for CCL text as it is typed.
 package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -18,8 +22,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/catconflang/ccl-test-data/config"
-	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/catconflang/ccl-test-data/internal/implementation"
 	"github.com/catconflang/ccl-test-data/types"
 )
 
@@ -205,7 +208,7 @@ type FileInfo struct {
 	Name        string
 	Description string
 	TestCount   int
-	ParseTests  int
+	Validations int // Flat tests the file's tests expand to
 }
 
 func getJSONFiles(dir string) ([]FileInfo, error) {
@@ -228,25 +231,12 @@ func getJSONFiles(dir string) ([]FileInfo, error) {
 				var suite TestSuite
 				if err := json.Unmarshal(data, &suite); err == nil {
 					fileInfo.Description = suite.Description
-					fileInfo.TestCount = len(suite.Tests)
-
-					// Count parse tests (parse and parse_indented)
-					parseCount := 0
-					for _, test := range suite.Tests {
-						// Check if test has parse or parse_indented validation
-						hasParse := false
-						if test.Validations != nil {
-							if test.Validations.Parse != nil || test.Validations.ParseIndented != nil {
-								hasParse = true
-							}
-						} else if test.Validation == "parse" || test.Validation == "parse_indented" {
-							hasParse = true
-						}
-						if hasParse {
-							parseCount++
-						}
-					}
-					fileInfo.ParseTests = parseCount
+				}
+			}
+			if suite, err := loadSuite(filePath); err == nil {
+				fileInfo.TestCount = len(suite.Tests)
+				for _, test := range suite.Tests {
+					fileInfo.Validations += len(validations(test))
 				}
 			}
 
@@ -262,7 +252,7 @@ func getJSONFiles(dir string) ([]FileInfo, error) {
 	return jsonFiles, nil
 }
 
-func runFileSelectionCLI(dir string) {
+func runFileSelectionCLI(dir string, source resultSource) {
 	files, err := getJSONFiles(dir)
 	if err != nil {
 		log.Printf("Error reading directory %s: %v", dir, err)
@@ -288,7 +278,7 @@ func runFileSelectionCLI(dir string) {
 		if file.Description != "" {
 			fmt.Printf("    %s\n", infoStyle.Render(file.Description))
 		}
-		fmt.Printf("    %s\n", infoStyle.Render(fmt.Sprintf("Total: %d tests, Parse/ParseIndented: %d tests", file.TestCount, file.ParseTests)))
+		fmt.Printf("    %s\n", infoStyle.Render(fmt.Sprintf("Total: %d tests, %d validations", file.TestCount, file.Validations)))
 		fmt.Println()
 	}
 
@@ -311,71 +301,139 @@ func runFileSelectionCLI(dir string) {
 	fmt.Printf("\nStarting TUI for: %s\n", selectedFile.Name)
 
 	// Run TUI for the selected file
-	runTUI(selectedFile.Path)
+	runTUI(selectedFile.Path, source)
 }
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: test-reader <test-file.json|directory> [--static]")
+	opts, err := parseArgs(os.Args[1:])
+	if err != nil {
+		if err != errNoPath {
+			fmt.Println("Error:", err)
+		}
+		fmt.Println("Usage: test-reader <test-file.json|directory> [--static] [--results FILE | --impl SPEC]")
+		fmt.Println("       test-reader --corpus [directory] [--results FILE | --impl SPEC]")
+		fmt.Println("       test-reader --playground [directory] [--save-to FILE]")
 		fmt.Println("       test-reader tests/                              # Interactive TUI (default)")
 		fmt.Println("       test-reader tests/api_essential-parsing.json   # Interactive TUI (default)")
 		fmt.Println("       test-reader tests/ --static                     # Static CLI output")
 		fmt.Println("       test-reader tests/api_essential-parsing.json --static")
+		fmt.Println("       test-reader generated_tests/ --results results.json  # Overlay a conformance run")
+		fmt.Println("       test-reader generated_tests/ --impl mock             # Run an implementation")
+		fmt.Println("       test-reader --corpus                                 # Search every flat test")
 		os.Exit(1)
 	}
 
-	path := os.Args[1]
-	useStatic := len(os.Args) > 2 && os.Args[2] == "--static"
+	path, useStatic, source := opts.path, opts.static, opts.source
+	playgroundFile = opts.saveTo
+	if opts.playground {
+		runPlayground(playgroundSavePath(path))
+		return
+	}
+	if opts.corpus {
+		runCorpusTUI(corpusRoot(path), "", source, false)
+		return
+	}
 
 	// Check if path is a directory
 	if info, err := os.Stat(path); err == nil && info.IsDir() {
 		if useStatic {
-			runFileSelectionCLI(path)
+			runFileSelectionCLI(path, source)
 		} else {
-			runFileSelectionTUI(path) // TUI is default for directories
+			runFileSelectionTUI(path, source) // TUI is default for directories
 		}
 	} else {
 		// Handle as single file
 		if useStatic {
-			if err := processTestFile(path); err != nil {
+			if err := processTestFile(path, source); err != nil {
 				log.Printf("Error processing %s: %v", path, err)
 			}
 		} else {
-			runTUI(path) // TUI is default for files
+			runTUI(path, source) // TUI is default for files
 		}
 	}
 }
 
-func processTestFile(filename string) error {
-	// TODO: Remove support for source format files completely.
-	// The test-reader should only work with generated flat format files.
-	// Source format files should be converted to generated format first.
-
-	// Detect if this is a source format file and emit warning
-	isSourceFormat := strings.Contains(filename, "source_tests")
-	if isSourceFormat {
-		warningStyle := lipgloss.NewStyle().Foreground(warningColor).Bold(true)
-		fmt.Println(warningStyle.Render("⚠️  WARNING: Source format files have limited display support."))
-		fmt.Println(warningStyle.Render("   Use generated format files for full entry details."))
-		fmt.Println(warningStyle.Render("   Run 'just generate' to convert source tests to generated format."))
-		fmt.Println()
+var errNoPath = errors.New("no test file or directory given")
+
+// options are the command line arguments of the test-reader
+type options struct {
+	path   string
+	static bool
+	corpus bool // Browse every flat test in the corpus holding path
+	source resultSource
+
+	playground bool   // Open the playground instead of a test file
+	saveTo     string // Source file playground tests are saved to
+}
+
+// parseArgs reads the path and options. Options may appear before or after the path.
+func parseArgs(args []string) (options, error) {
+	var opts options
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "--static":
+			opts.static = true
+		case "--corpus":
+			opts.corpus = true
+		case "--playground":
+			opts.playground = true
+		case "--results", "--impl", "--save-to":
+			if i+1 == len(args) {
+				return opts, fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			switch arg {
+			case "--results":
+				opts.source.resultsFile = args[i]
+			case "--impl":
+				opts.source.impl = args[i]
+			case "--save-to":
+				opts.saveTo = args[i]
+			}
+		default:
+			if strings.HasPrefix(arg, "--") || opts.path != "" {
+				return opts, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.path = arg
+		}
 	}
 
-	// Use ccl-test-lib loader to handle both source and flat formats
-	impl := config.ImplementationConfig{
-		Name:               "test-reader",
-		Version:            "1.0.0",
-		SupportedFunctions: []config.CCLFunction{config.FunctionParse, config.FunctionParseIndented}, // Support parse and parse_indented tests for display
+	switch {
+	case (opts.corpus || opts.playground) && opts.static:
+		return opts, errors.New("--corpus and --playground are only available in the interactive TUI")
+	case opts.path == "" && (opts.corpus || opts.playground):
+		opts.path = "."
+	case opts.path == "":
+		return opts, errNoPath
 	}
-	testLoader := loader.NewTestLoader(".", impl)
-	suite, err := testLoader.LoadTestFile(filename, loader.LoadOptions{
-		Format:     loader.FormatFlat,
-		FilterMode: loader.FilterAll, // Load all tests for viewer
-	})
+	if opts.source.resultsFile != "" && opts.source.impl != "" {
+		return opts, errors.New("--results and --impl cannot be used together")
+	}
+	return opts, nil
+}
+
+func processTestFile(filename string, source resultSource) error {
+	suite, err := loadSuite(filename)
 	if err != nil {
 		return fmt.Errorf("loading test suite: %w", err)
 	}
 
+	// Overlay implementation results as the TUI does; without a source nothing is shown
+	var overlay tuiModel
+	if source.enabled() {
+		loaded := loadResultsCmd(source, suite.Tests)().(resultsLoadedMsg)
+		if loaded.err != nil {
+			return fmt.Errorf("loading results: %w", loaded.err)
+		}
+		overlay = tuiModel{
+			allTests:     suite.Tests,
+			width:        100,
+			resultSource: source,
+			results:      loaded.results,
+			implName:     loaded.implementation,
+		}
+	}
+
 	// Suite header with styled box
 	header := fmt.Sprintf("%s", suite.Suite)
 	info := fmt.Sprintf("File: %s | %s", filepath.Base(filename), suite.Description)
@@ -384,20 +442,21 @@ func processTestFile(filename string) error {
 	fmt.Println(suiteInfoStyle.Render(info))
 	fmt.Println()
 
-	parseOnlyCount := 0
-	for _, test := range suite.Tests {
-		if hasParseValidation(test) {
-			parseOnlyCount++
-			displayTest(test, parseOnlyCount)
-		}
+	validationCount := 0
+	for i, test := range suite.Tests {
+		displayTest(test, i+1, overlay)
+		validationCount += len(validations(test))
 	}
 
 	// Summary with styled box
-	if parseOnlyCount == 0 {
-		summary := "📋 No parse tests (parse/parse_indented) found in this file"
-		fmt.Println(summaryStyle.Render(summary))
+	if len(suite.Tests) == 0 {
+		fmt.Println(summaryStyle.Render("📋 No tests found in this file"))
 	} else {
-		summary := fmt.Sprintf("📊 Found %d parse test(s) (parse/parse_indented)", parseOnlyCount)
+		summary := fmt.Sprintf("📊 Found %d test(s) with %d validation(s)", len(suite.Tests), validationCount)
+		if source.enabled() {
+			passed, failed := overlay.resultCounts()
+			summary += fmt.Sprintf(" • %s: %d passed, %d failed", overlay.implName, passed, failed)
+		}
 		fmt.Println(summaryStyle.Render(summary))
 	}
 	fmt.Println()
@@ -405,211 +464,62 @@ func processTestFile(filename string) error {
 	return nil
 }
 
-func hasParseValidation(test TestCase) bool {
-	// ccl-test-lib TestCase should have parse functionality built-in
-	// For now, assume all loaded tests are valid parse tests
-	return true
-}
-
-func displayTest(test TestCase, index int) {
+// displayTest prints a test, with the result of each validation when overlay has results
+func displayTest(test TestCase, index int, overlay tuiModel) {
 	// Test header
 	header := fmt.Sprintf("Test #%d: %s", index, test.Name)
 	fmt.Println(testHeaderStyle.Render(header))
 
-	// Note: ccl-test-lib TestCase doesn't have Description field
-	// Description would be in suite metadata if needed
-
-	// Display input compactly (use first input for single-input tests)
-	fmt.Println(inputHeaderStyle.Render("📄 CCL INPUT:"))
-	inputText := ""
-	if len(test.Inputs) > 0 {
-		inputText = test.Inputs[0]
+	fmt.Print(renderInputs(test))
+	for _, v := range validations(test) {
+		fmt.Print(renderValidation(v, renderOptions{static: true}))
+		fmt.Print(overlay.renderResult(v.test, false))
 	}
-	fmt.Println(inputContentStyle.Render(formatInputContent(inputText)))
-
-	// Display parse validation - use the Expected field from ccl-test-lib
-	displayParseValidationFromTestCase(test)
-
-	// Display selective metadata
-	displaySelectiveMetadata(test)
+	fmt.Print(renderMetadata(test))
 	fmt.Println()
 }
 
-func displayParseValidationFromTestCase(test TestCase) {
-	// Handle successful parse case using ccl-test-lib TestCase
-	fmt.Println(successHeaderStyle.Render("✅ EXPECTED: Parse Success"))
-
-	// Handle Expected field which can be array of entries or map with entries
-	var entries []Entry
-	var count int
-
-	// Try to extract entries from Expected interface{}
-	if test.Expected != nil {
-		switch expected := test.Expected.(type) {
-		case []interface{}:
-			// Direct array of entries
-			for _, item := range expected {
-				if entryMap, ok := item.(map[string]interface{}); ok {
-					key, _ := entryMap["key"].(string)
-					value, _ := entryMap["value"].(string)
-					entries = append(entries, Entry{Key: key, Value: value})
-				}
-			}
-			count = len(entries)
-		case map[string]interface{}:
-			// Map with count and entries fields
-			if c, ok := expected["count"]; ok {
-				if countFloat, ok := c.(float64); ok {
-					count = int(countFloat)
-				}
-			}
-			if entriesArray, ok := expected["entries"].([]interface{}); ok {
-				for _, item := range entriesArray {
-					if entryMap, ok := item.(map[string]interface{}); ok {
-						key, _ := entryMap["key"].(string)
-						value, _ := entryMap["value"].(string)
-						entries = append(entries, Entry{Key: key, Value: value})
-					}
-				}
-			}
-		}
-	}
-
-	fmt.Printf("   Count: %d assertion(s)\n", count)
-
-	if len(entries) > 0 {
-		totalEntries := len(entries)
-		fmt.Printf("   Entries (%d total):\n", totalEntries)
-
-		// Show up to maxEntriesDisplay entries
-		entriesToShow := entries
-		if totalEntries > maxEntriesDisplay {
-			entriesToShow = entries[:maxEntriesDisplay]
-		}
-
-		for _, entry := range entriesToShow {
-			// Display key=value on same line unless value contains newlines
-			if strings.Contains(entry.Value, "\n") {
-				// Multiline value: key on first line, value on subsequent lines
-				keyLine := fmt.Sprintf("%s %s", formatKey(entry.Key), entryEqualsStyle.Render("="))
-				valueLine := formatValue(entry.Value)
-				entryContent := fmt.Sprintf("%s\n%s", keyLine, valueLine)
-				fmt.Println(entryBoxStyle.Render(entryContent))
-			} else {
-				// Single line: key = value
-				entryContent := fmt.Sprintf("%s %s %s", formatKey(entry.Key), entryEqualsStyle.Render("="), formatValue(entry.Value))
-				fmt.Println(entryBoxStyle.Render(entryContent))
-			}
-		}
-
-		// Show truncation indicator if there are more entries
-		if totalEntries > maxEntriesDisplay {
-			remaining := totalEntries - maxEntriesDisplay
-			truncationMsg := fmt.Sprintf("... and %d more entries (use TUI mode for scrolling)", remaining)
-			truncationStyle := lipgloss.NewStyle().Foreground(subtleColor)
-			fmt.Println(truncationStyle.Render("   " + truncationMsg))
-		}
-	}
-}
-
-func displayParseValidation(parseData interface{}) {
-	// Convert to map first to handle the interface{}
-	parseMap, ok := parseData.(map[string]interface{})
-	if !ok {
-		fmt.Println(errorHeaderStyle.Render("❌ Invalid parse validation format"))
-		return
-	}
-
-	count, _ := parseMap["count"].(float64) // JSON numbers are float64
-
-	// Check if this is an error case
-	if errorVal, hasError := parseMap["error"]; hasError && errorVal == true {
-		fmt.Println(errorHeaderStyle.Render("❌ EXPECTED: Parse Error"))
-		fmt.Printf("   Count: %.0f assertion(s)\n", count)
-
-		if errorMsg, ok := parseMap["error_message"].(string); ok {
-			fmt.Printf("   Error: %s\n", errorMsg)
-		}
-		return
-	}
-
-	// Handle successful parse case
-	fmt.Println(successHeaderStyle.Render("✅ EXPECTED: Parse Success"))
-	fmt.Printf("   Count: %.0f assertion(s)\n", count)
-
-	if expectedData, ok := parseMap["expected"].([]interface{}); ok {
-		totalEntries := len(expectedData)
-		fmt.Printf("   Entries (%d total):\n", totalEntries)
-
-		// Show up to maxEntriesDisplay entries
-		entriesToShow := expectedData
-		if totalEntries > maxEntriesDisplay {
-			entriesToShow = expectedData[:maxEntriesDisplay]
-		}
-
-		for _, entryData := range entriesToShow {
-			if entryMap, ok := entryData.(map[string]interface{}); ok {
-				key, _ := entryMap["key"].(string)
-				value, _ := entryMap["value"].(string)
-
-				// Boxed entry content with key/equals on first line, value on second
-				keyLine := fmt.Sprintf("%s %s", formatKey(key), entryEqualsStyle.Render("="))
-				valueLine := formatValue(value)
-				entryContent := fmt.Sprintf("%s\n%s", keyLine, valueLine)
-				fmt.Println(entryBoxStyle.Render(entryContent))
-			}
-		}
-
-		// Show truncation indicator if there are more entries
-		if totalEntries > maxEntriesDisplay {
-			remaining := totalEntries - maxEntriesDisplay
-			truncationMsg := fmt.Sprintf("... and %d more entries (use TUI mode for scrolling)", remaining)
-			truncationStyle := lipgloss.NewStyle().Foreground(subtleColor)
-			fmt.Println(truncationStyle.Render("   " + truncationMsg))
-		}
-	}
-}
+// renderMetadata shows a test's behavior, variant and feature tags and its conflicts
+func renderMetadata(test TestCase) string {
+	var content strings.Builder
 
-func displaySelectiveMetadata(test TestCase) {
-	// Show behavior tags if available in ccl-test-lib TestCase
 	variantTags := []string{}
-
-	// Add behaviors to variant tags
 	for _, behavior := range test.Behaviors {
 		variantTags = append(variantTags, "behavior:"+behavior)
 	}
-
-	// Add any other relevant metadata from TestCase
-	if len(test.Features) > 0 {
-		for _, feature := range test.Features {
-			variantTags = append(variantTags, "feature:"+feature)
-		}
+	for _, variant := range test.Variants {
+		variantTags = append(variantTags, "variant:"+variant)
+	}
+	for _, feature := range test.Features {
+		variantTags = append(variantTags, "feature:"+feature)
 	}
 
 	if len(variantTags) > 0 {
-		fmt.Println(metaHeaderStyle.Render("🔄 VARIANTS:"))
-		fmt.Print("   ")
+		content.WriteString(metaHeaderStyle.Render("🔄 VARIANTS:") + "\n")
+		content.WriteString("   ")
 		for i, tag := range variantTags {
 			if i > 0 {
-				fmt.Print(", ")
+				content.WriteString(", ")
 			}
-			fmt.Print(tagStyle.Render(tag))
+			content.WriteString(tagStyle.Render(tag))
 		}
-		fmt.Println()
+		content.WriteString("\n")
 	}
 
-	// Note: ccl-test-lib TestCase might not have Conflicts field
-	// This functionality may need to be adapted based on the actual structure
+	content.WriteString(renderConflicts(test.Conflicts))
+	return content.String()
 }
 
 // File Selection TUI Model
 type fileSelectionModel struct {
-	files        []FileInfo
-	directory    string
-	selectedFile int
-	width        int
-	height       int
-	fileSelected bool
+	files          []FileInfo
+	directory      string
+	selectedFile   int
+	width          int
+	height         int
+	fileSelected   bool
+	corpusSelected bool // Browse every test in the corpus instead of one file
+	searchSelected bool // Start the corpus view with a search
 }
 
 func initialFileSelectionModel(dir string) fileSelectionModel {
@@ -656,6 +566,10 @@ func (m fileSelectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.fileSelected = true
 				return m, tea.Quit
 			}
+		case "c", "/":
+			m.corpusSelected = true
+			m.searchSelected = msg.String() == "/"
+			return m, tea.Quit
 		}
 	}
 	return m, nil
@@ -696,7 +610,7 @@ func (m fileSelectionModel) View() string {
 
 		// Stats line for selected file
 		if i == m.selectedFile {
-			stats := fmt.Sprintf("   Total: %d tests, Parse/ParseValue: %d tests", file.TestCount, file.ParseTests)
+			stats := fmt.Sprintf("   Total: %d tests, %d validations", file.TestCount, file.Validations)
 			statsStyle := lipgloss.NewStyle().Foreground(subtleColor)
 			fileList.WriteString(statsStyle.Render(stats) + "\n")
 		}
@@ -707,13 +621,13 @@ func (m fileSelectionModel) View() string {
 	content.WriteString(fileListStyle.Render(fileList.String()) + "\n")
 
 	// Navigation help
-	help := "j/k: navigate • g/G: first/last • enter/space: select • q/esc: quit"
+	help := "j/k: navigate • g/G: first/last • enter/space: select • c: all tests • /: search all tests • q/esc: quit"
 	content.WriteString(suiteInfoStyle.Render(help))
 
 	return content.String()
 }
 
-func runFileSelectionTUI(dir string) {
+func runFileSelectionTUI(dir string, source resultSource) {
 	files, err := getJSONFiles(dir)
 	if err != nil {
 		log.Printf("Error reading directory %s: %v", dir, err)
@@ -735,17 +649,23 @@ func runFileSelectionTUI(dir string) {
 		os.Exit(1)
 	}
 
+	if fsModel, ok := finalModel.(fileSelectionModel); ok && fsModel.corpusSelected {
+		runCorpusTUI(corpusRoot(dir), dir, source, fsModel.searchSelected)
+		return
+	}
+
 	// Check if a file was selected
 	if fsModel, ok := finalModel.(fileSelectionModel); ok && fsModel.fileSelected && fsModel.selectedFile >= 0 && fsModel.selectedFile < len(fsModel.files) {
 		selectedFile := fsModel.files[fsModel.selectedFile]
 		// Run TUI with directory context for back navigation
-		runTUIWithBackNav(selectedFile.Path, dir)
+		runTUIWithBackNav(selectedFile.Path, dir, source)
 	}
 }
 
 // TUI Implementation
 type tuiModel struct {
-	tests       []TestCase
+	tests       []TestCase // Visible tests
+	allTests    []TestCase // Every test in the file
 	suite       TestSuite
 	filename    string
 	directory   string // For back navigation
@@ -755,11 +675,38 @@ type tuiModel struct {
 	height      int
 	wantsBack   bool // Track if user wants to go back
 	entryScroll int  // Current entry scroll offset
+
+	// Implementation result overlay
+	resultSource resultSource
+	results      map[string]implementation.TestResult // Keyed by test name, nil until loaded
+	implName     string
+	resultsErr   error
+	failuresOnly bool // Show only failing tests
+
+	// Search and faceted filtering
+	filter       testFilter
+	searching    bool // Typing a search query
+	picking      bool // Facet picker is open
+	pickerCursor int
+
+	// Cross-file corpus mode
+	corpus     bool
+	corpusRoot string            // Directory holding generated_tests
+	testFiles  map[string]string // Source file of each test, keyed by test name
+
+	playground *playground // Open playground, if any
+	trace      *traceView  // Open trace, if any
+
+	pendingTest string // Test to select once the file being loaded arrives
+
+	loadErr error
 }
 
 type testLoadedMsg struct {
 	suite    TestSuite
 	filename string
+	files    map[string]string // Source file of each test in corpus mode
+	err      error
 }
 
 func initialTUIModel() tuiModel {
@@ -773,22 +720,9 @@ func initialTUIModel() tuiModel {
 
 func loadTestFileCmd(filename string) tea.Cmd {
 	return func() tea.Msg {
-		// TODO: Remove support for source format files completely.
-		// The test-reader should only work with generated flat format files.
-
-		// Use ccl-test-lib loader to handle both source and flat formats
-		impl := config.ImplementationConfig{
-			Name:               "test-reader",
-			Version:            "1.0.0",
-			SupportedFunctions: []config.CCLFunction{config.FunctionParse, config.FunctionParseIndented}, // Support parse and parse_indented tests for display
-		}
-		testLoader := loader.NewTestLoader(".", impl)
-		suite, err := testLoader.LoadTestFile(filename, loader.LoadOptions{
-			Format:     loader.FormatFlat,
-			FilterMode: loader.FilterAll, // Load all tests for viewer
-		})
+		suite, err := loadSuite(filename)
 		if err != nil {
-			return nil
+			return testLoadedMsg{err: fmt.Errorf("loading %s: %w", filename, err)}
 		}
 
 		return testLoadedMsg{
@@ -799,6 +733,9 @@ func loadTestFileCmd(filename string) tea.Cmd {
 }
 
 func (m tuiModel) Init() tea.Cmd {
+	if m.corpus {
+		return loadCorpusCmd(m.corpusRoot)
+	}
 	if m.filename != "" {
 		return loadTestFileCmd(m.filename)
 	}
@@ -808,22 +745,103 @@ func (m tuiModel) Init() tea.Cmd {
 func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case testLoadedMsg:
+		if msg.err != nil {
+			m.loadErr = msg.err
+			return m, nil
+		}
 		m.suite = msg.suite
 		m.filename = msg.filename
+		m.testFiles = msg.files
 		// ccl-test-lib loader already filters to appropriate tests
-		m.tests = msg.suite.Tests
+		m.allTests = msg.suite.Tests
+		m.applyFilters()
+		if m.pendingTest != "" {
+			m.selectTest(m.pendingTest)
+			m.pendingTest = ""
+		}
+		if m.resultSource.enabled() {
+			return m, loadResultsCmd(m.resultSource, m.allTests)
+		}
+		return m, nil
+
+	case traceLoadedMsg:
+		if m.trace != nil {
+			m.trace.trace, m.trace.err, m.trace.loading = msg.trace, msg.err, false
+			if msg.trace != nil {
+				// Start on the flat test the trace was opened from
+				for i, flat := range msg.trace.Flat {
+					if flat.Test.Name == m.trace.name {
+						m.trace.cursor = i
+					}
+				}
+			}
+		}
+		return m, nil
+
+	case resultsLoadedMsg:
+		m.results = msg.results
+		m.implName = msg.implementation
+		m.resultsErr = msg.err
 		return m, nil
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		if m.playground != nil {
+			m.playground.width, m.playground.height = msg.Width, msg.Height
+		}
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.playground != nil {
+			cmd := m.playground.update(msg)
+			if m.playground.closed {
+				m.playground = nil
+			}
+			return m, cmd
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		if m.picking {
+			return m.updateFacetPicker(msg)
+		}
+		if m.trace != nil {
+			return m.updateTrace(msg)
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
+		case "/":
+			m.searching = true
+		case "F":
+			m.picking = true
+		case "t":
+			// Trace the current test to its source test and sibling flat tests
+			if m.currentTest < len(m.tests) {
+				name := m.tests[m.currentTest].Name
+				m.trace = &traceView{name: name, loading: true}
+				return m, traceCmd(m.traceRoot(), name)
+			}
+		case "p":
+			// Start the playground from the current test's input
+			input := ""
+			if m.currentTest < len(m.tests) && len(m.tests[m.currentTest].Inputs) > 0 {
+				input = m.tests[m.currentTest].Inputs[0]
+			}
+			dir := filepath.Dir(m.filename)
+			if m.corpus {
+				dir = m.corpusRoot
+			}
+			m.playground = newPlayground(input, playgroundSavePath(dir), m.width, m.height)
 		case "esc":
+			if m.filter.active() {
+				// Clear the search and facets before leaving the file
+				m.filter = testFilter{}
+				m.applyFilters()
+				return m, nil
+			}
 			if m.directory != "" {
 				// Set flag and quit to go back to directory selection
 				m.wantsBack = true
@@ -843,29 +861,31 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentTest = 0
 			m.entryScroll = 0
 		case "G":
-			m.currentTest = len(m.tests) - 1
+			if len(m.tests) > 0 {
+				m.currentTest = len(m.tests) - 1
+			}
 			m.entryScroll = 0
 		case "a":
 			m.showAll = !m.showAll
+		case "n":
+			m.jumpToFailure(1)
+		case "N":
+			m.jumpToFailure(-1)
+		case "f":
+			if _, failed := m.resultCounts(); failed > 0 || m.failuresOnly {
+				m.failuresOnly = !m.failuresOnly
+				m.applyFilters()
+			}
 		case "left", "h":
 			if m.entryScroll > 0 {
 				m.entryScroll--
 			}
 		case "right", "l":
-			// Scroll entries forward if there are more to show
+			// Scroll entries forward while any validation has more to show
 			if m.currentTest < len(m.tests) {
-				currentTest := m.tests[m.currentTest]
-				// Extract entry count from Expected interface{}
 				entryCount := 0
-				if currentTest.Expected != nil {
-					switch expected := currentTest.Expected.(type) {
-					case []interface{}:
-						entryCount = len(expected)
-					case map[string]interface{}:
-						if entriesArray, ok := expected["entries"].([]interface{}); ok {
-							entryCount = len(entriesArray)
-						}
-					}
+				for _, v := range validations(m.tests[m.currentTest]) {
+					entryCount = max(entryCount, scrollLength(v))
 				}
 				maxScroll := entryCount - maxEntriesDisplay
 				if maxScroll > 0 && m.entryScroll < maxScroll {
@@ -878,20 +898,37 @@ func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m tuiModel) View() string {
-	if len(m.tests) == 0 {
+	if m.loadErr != nil {
+		return errorHeaderStyle.Render(fmt.Sprintf("❌ %v", m.loadErr)) + "\n\n" + suiteInfoStyle.Render("q: quit")
+	}
+	if len(m.allTests) == 0 {
 		return fmt.Sprintf("Loading... (tests=%d, suite=%s, filename=%s)", len(m.tests), m.suite.Suite, m.filename)
 	}
+	if m.picking {
+		return m.renderFacetPicker()
+	}
+	if m.playground != nil {
+		return m.playground.view()
+	}
+	if m.trace != nil {
+		return m.renderTrace()
+	}
 
 	var content strings.Builder
 
 	// Header
 	header := fmt.Sprintf("%s", m.suite.Suite)
 	info := fmt.Sprintf("File: %s | %s", filepath.Base(m.filename), m.suite.Description)
+	if m.corpus {
+		info = fmt.Sprintf("Corpus: %s | %s", m.filename, m.suite.Description)
+	}
 
 	content.WriteString(suiteHeaderStyle.Render(header) + "\n")
 	content.WriteString(suiteInfoStyle.Render(info) + "\n\n")
 
-	if m.showAll {
+	if len(m.tests) == 0 {
+		content.WriteString(metaHeaderStyle.Render("No tests match the current filters") + "\n")
+	} else if m.showAll {
 		// Show all tests
 		for i, test := range m.tests {
 			if i == m.currentTest {
@@ -908,13 +945,27 @@ func (m tuiModel) View() string {
 	}
 
 	// Navigation info
-	navInfo := fmt.Sprintf("Test %d of %d", m.currentTest+1, len(m.tests))
-	help := "j/k: navigate • g/G: first/last • a: toggle all • h/l: scroll entries • q: quit"
-	if m.directory != "" {
+	navInfo := fmt.Sprintf("Test %d of %d", min(m.currentTest+1, len(m.tests)), len(m.tests))
+	help := "j/k: navigate • g/G: first/last • a: toggle all • h/l: scroll entries • /: search • F: facets • t: trace • p: playground • q: quit"
+	if m.results != nil {
+		passed, failed := m.resultCounts()
+		navInfo += fmt.Sprintf(" • %s: %d passed, %d failed", m.implName, passed, failed)
+		if m.failuresOnly {
+			navInfo += " (showing failures only)"
+		}
+		help += " • n/N: next/prev failure • f: toggle failures only"
+	}
+	if m.filter.active() {
+		help += " • esc: clear filters"
+	} else if m.directory != "" {
 		help += " • esc: back to file selection"
 	}
+	if m.searching {
+		help = "type to search names and inputs • enter: done • esc: clear search"
+	}
 
 	content.WriteString("\n")
+	content.WriteString(m.renderFilterStatus())
 	content.WriteString(summaryStyle.Render(navInfo) + "\n")
 	content.WriteString(suiteInfoStyle.Render(help))
 
@@ -927,10 +978,10 @@ func (m tuiModel) renderTestSummary(test TestCase, index int) string {
 		prefix = "► "
 	}
 
-	status := "✅" // ccl-test-lib tests are typically valid parse tests
-	// TODO: Add error detection logic based on ccl-test-lib TestCase structure
-
-	summary := fmt.Sprintf("%s%s %s", prefix, status, test.Name)
+	summary := fmt.Sprintf("%s%s %s", prefix, m.statusIcon(test), test.Name)
+	if file := m.renderSourceFile(test); file != "" {
+		summary += "  " + file
+	}
 	if index == m.currentTest+1 {
 		return testHeaderStyle.Render(summary)
 	}
@@ -943,266 +994,69 @@ func (m tuiModel) renderTest(test TestCase, index int, compact bool) string {
 	// Test header
 	header := fmt.Sprintf("Test #%d: %s", index, test.Name)
 	content.WriteString(testHeaderStyle.Render(header) + "\n")
+	if file := m.renderSourceFile(test); file != "" {
+		content.WriteString("📁 " + file + "\n")
+	}
 
-	// Note: ccl-test-lib TestCase doesn't have Description field
-	// Description would be in suite metadata if needed
+	content.WriteString(renderInputs(test))
 
-	// Input CCL compact (use first input for single-input tests)
-	inputText := ""
-	if len(test.Inputs) > 0 {
-		inputText = test.Inputs[0]
+	// Each validation with the implementation's result, when an overlay is loaded
+	opts := renderOptions{compact: compact, scroll: m.entryScroll}
+	for _, v := range validations(test) {
+		content.WriteString(renderValidation(v, opts))
+		content.WriteString(m.renderResult(v.test, compact))
 	}
-	content.WriteString(inputHeaderStyle.Render("📄 CCL INPUT:") + "\n")
-	content.WriteString(inputContentStyle.Render(formatInputContent(inputText)) + "\n")
-
-	// Parse validation using ccl-test-lib TestCase
-	content.WriteString(m.renderParseValidationFromTestCase(test, compact) + "\n")
 
 	// Selective metadata (only if not compact)
 	if !compact {
-		content.WriteString(m.renderSelectiveMetadata(test) + "\n")
+		content.WriteString("\n" + renderMetadata(test) + "\n")
 	}
 
 	return content.String()
 }
 
-func (m tuiModel) renderParseValidationFromTestCase(test TestCase, compact bool) string {
-	var content strings.Builder
-
-	// Handle successful parse case using ccl-test-lib TestCase
-	content.WriteString(successHeaderStyle.Render("✅ EXPECTED: Parse Success") + "\n")
-
-	// Handle Expected field which can be array of entries or map with entries
-	var entries []Entry
-	var count int
-
-	// Try to extract entries from Expected interface{}
-	if test.Expected != nil {
-		switch expected := test.Expected.(type) {
-		case []interface{}:
-			// Direct array of entries
-			for _, item := range expected {
-				if entryMap, ok := item.(map[string]interface{}); ok {
-					key, _ := entryMap["key"].(string)
-					value, _ := entryMap["value"].(string)
-					entries = append(entries, Entry{Key: key, Value: value})
-				}
-			}
-			count = len(entries)
-		case map[string]interface{}:
-			// Map with count and entries fields
-			if c, ok := expected["count"]; ok {
-				if countFloat, ok := c.(float64); ok {
-					count = int(countFloat)
-				}
-			}
-			if entriesArray, ok := expected["entries"].([]interface{}); ok {
-				for _, item := range entriesArray {
-					if entryMap, ok := item.(map[string]interface{}); ok {
-						key, _ := entryMap["key"].(string)
-						value, _ := entryMap["value"].(string)
-						entries = append(entries, Entry{Key: key, Value: value})
-					}
-				}
-			}
-		}
-	}
-
-	content.WriteString(fmt.Sprintf("   Count: %d assertion(s)\n", count))
-
-	if !compact && len(entries) > 0 {
-		totalEntries := len(entries)
-		content.WriteString(fmt.Sprintf("   Entries (%d total):\n", totalEntries))
-
-		// Handle scrolling
-		startIdx := m.entryScroll
-		if startIdx >= totalEntries {
-			startIdx = totalEntries - 1
-			if startIdx < 0 {
-				startIdx = 0
-			}
-		}
-
-		endIdx := startIdx + maxEntriesDisplay
-		if endIdx > totalEntries {
-			endIdx = totalEntries
-		}
-
-		// Show scroll indicators if needed
-		if startIdx > 0 {
-			scrollStyle := lipgloss.NewStyle().Foreground(subtleColor)
-			content.WriteString(scrollStyle.Render("   ↑ More entries above (h/← to scroll up)\n"))
-		}
-
-		// Show entries in current scroll window
-		for i := startIdx; i < endIdx; i++ {
-			entry := entries[i]
-
-			// Display key=value on same line unless value contains newlines
-			if strings.Contains(entry.Value, "\n") {
-				// Multiline value: key on first line, value on subsequent lines
-				keyLine := fmt.Sprintf("%s %s", formatKey(entry.Key), entryEqualsStyle.Render("="))
-				valueLine := formatValue(entry.Value)
-				entryContent := fmt.Sprintf("%s\n%s", keyLine, valueLine)
-				content.WriteString(entryBoxStyle.Render(entryContent) + "\n")
-			} else {
-				// Single line: key = value
-				entryContent := fmt.Sprintf("%s %s %s", formatKey(entry.Key), entryEqualsStyle.Render("="), formatValue(entry.Value))
-				content.WriteString(entryBoxStyle.Render(entryContent) + "\n")
-			}
-		}
-
-		// Show scroll indicator if there are more entries below
-		if endIdx < totalEntries {
-			remaining := totalEntries - endIdx
-			scrollStyle := lipgloss.NewStyle().Foreground(subtleColor)
-			content.WriteString(scrollStyle.Render(fmt.Sprintf("   ↓ %d more entries below (l/→ to scroll down)\n", remaining)))
-		}
-	}
-
-	return content.String()
-}
-
-func (m tuiModel) renderSelectiveMetadata(test TestCase) string {
-	var content strings.Builder
-
-	// Show behavior tags if available in ccl-test-lib TestCase
-	variantTags := []string{}
-
-	// Add behaviors to variant tags
-	for _, behavior := range test.Behaviors {
-		variantTags = append(variantTags, "behavior:"+behavior)
-	}
-
-	// Add any other relevant metadata from TestCase
-	if len(test.Features) > 0 {
-		for _, feature := range test.Features {
-			variantTags = append(variantTags, "feature:"+feature)
-		}
-	}
+func runTUI(filename string, source resultSource) {
+	model := initialTUIModel()
+	model.filename = filename
+	model.resultSource = source
 
-	if len(variantTags) > 0 {
-		content.WriteString(metaHeaderStyle.Render("🔄 VARIANTS:") + "\n")
-		content.WriteString("   ")
-		for i, tag := range variantTags {
-			if i > 0 {
-				content.WriteString(", ")
-			}
-			content.WriteString(tagStyle.Render(tag))
-		}
-		content.WriteString("\n")
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error running TUI: %v", err)
+		os.Exit(1)
 	}
-
-	return content.String()
 }
 
-func (m tuiModel) renderParseValidation(parseData interface{}, compact bool) string {
-	parseMap, ok := parseData.(map[string]interface{})
-	if !ok {
-		return errorHeaderStyle.Render("❌ Invalid parse validation format")
-	}
-
-	count, _ := parseMap["count"].(float64)
-
-	// Check if this is an error case
-	if errorVal, hasError := parseMap["error"]; hasError && errorVal == true {
-		var content strings.Builder
-		content.WriteString(errorHeaderStyle.Render("❌ EXPECTED: Parse Error") + "\n")
-		content.WriteString(fmt.Sprintf("   Count: %.0f assertion(s)\n", count))
-
-		if errorMsg, ok := parseMap["error_message"].(string); ok {
-			content.WriteString(fmt.Sprintf("   Error: %s\n", errorMsg))
-		}
-		return content.String()
-	}
-
-	// Handle successful parse case
-	var content strings.Builder
-	content.WriteString(successHeaderStyle.Render("✅ EXPECTED: Parse Success") + "\n")
-	content.WriteString(fmt.Sprintf("   Count: %.0f assertion(s)\n", count))
-
-	if expectedData, ok := parseMap["expected"].([]interface{}); ok && !compact {
-		totalEntries := len(expectedData)
-		content.WriteString(fmt.Sprintf("   Entries (%d total):\n", totalEntries))
-
-		// Handle scrolling
-		startIdx := m.entryScroll
-		if startIdx >= totalEntries {
-			startIdx = totalEntries - 1
-			if startIdx < 0 {
-				startIdx = 0
-			}
-		}
-
-		endIdx := startIdx + maxEntriesDisplay
-		if endIdx > totalEntries {
-			endIdx = totalEntries
-		}
-
-		// Show scroll indicators if needed
-		if startIdx > 0 {
-			scrollStyle := lipgloss.NewStyle().Foreground(subtleColor)
-			content.WriteString(scrollStyle.Render("   ↑ More entries above (h/← to scroll up)\n"))
-		}
-
-		// Show entries in current scroll window
-		for i := startIdx; i < endIdx; i++ {
-			entryData := expectedData[i]
-			if entryMap, ok := entryData.(map[string]interface{}); ok {
-				key, _ := entryMap["key"].(string)
-				value, _ := entryMap["value"].(string)
-
-				// Boxed entry content with key/equals on first line, value on second
-				keyLine := fmt.Sprintf("%s %s", formatKey(key), entryEqualsStyle.Render("="))
-				valueLine := formatValue(value)
-				entryContent := fmt.Sprintf("%s\n%s", keyLine, valueLine)
-				content.WriteString(entryBoxStyle.Render(entryContent) + "\n")
-			}
-		}
-
-		// Show scroll indicator if there are more entries below
-		if endIdx < totalEntries {
-			remaining := totalEntries - endIdx
-			scrollStyle := lipgloss.NewStyle().Foreground(subtleColor)
-			content.WriteString(scrollStyle.Render(fmt.Sprintf("   ↓ %d more entries below (l/→ to scroll down)\n", remaining)))
-		}
-	}
-
-	return content.String()
+func runTUIWithBackNav(filename, directory string, source resultSource) {
+	model := initialTUIModel()
+	model.filename = filename
+	model.directory = directory
+	model.resultSource = source
+	runTUIModelWithBackNav(model)
 }
 
-func runTUI(filename string) {
+// runCorpusTUI browses every flat test under root. A non-empty directory enables
+// back navigation to its file selection.
+func runCorpusTUI(root, directory string, source resultSource, search bool) {
 	model := initialTUIModel()
-	model.filename = filename
+	model.corpus = true
+	model.corpusRoot = root
+	model.directory = directory
+	model.resultSource = source
+	model.searching = search
+	runTUIModelWithBackNav(model)
+}
 
+func runTUIModelWithBackNav(model tuiModel) {
 	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if err != nil {
 		fmt.Printf("Error running TUI: %v", err)
 		os.Exit(1)
 	}
-}
-
-func runTUIWithBackNav(filename, directory string) {
-	for {
-		model := initialTUIModel()
-		model.filename = filename
-		model.directory = directory
 
-		p := tea.NewProgram(model, tea.WithAltScreen())
-		finalModel, err := p.Run()
-		if err != nil {
-			fmt.Printf("Error running TUI: %v", err)
-			os.Exit(1)
-		}
-
-		// Check if user pressed escape to go back
-		if tuiModel, ok := finalModel.(tuiModel); ok && tuiModel.wantsBack {
-			// Go back to directory selection
-			runFileSelectionTUI(directory)
-			break
-		} else {
-			// User quit normally, exit completely
-			break
-		}
+	// Check if user pressed escape to go back to directory selection
+	if tuiModel, ok := finalModel.(tuiModel); ok && tuiModel.wantsBack {
+		runFileSelectionTUI(model.directory, model.resultSource)
 	}
 }
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	ccl "github.com/catconflang/ccl-test-data"
+	"github.com/catconflang/ccl-test-data/generator"
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/internal/snapshot"
+	"github.com/catconflang/ccl-test-data/loader"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxOutputLines is the number of lines shown for each playground output
+const maxOutputLines = 12
+
+// defaultPlaygroundFile is where playground tests are saved, relative to the corpus root
+var defaultPlaygroundFile = filepath.Join("source_tests", "experimental", "api_playground.json")
+
+// playgroundFile overrides where playground tests are saved; set from --save-to
+var playgroundFile string
+
+// playgroundFunctions are evaluated live as the playground input changes. Their
+// results become the validations of a saved test.
+var playgroundFunctions = []string{"parse", "filter", "build_hierarchy", "canonical_format"}
+
+var testNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Playground styles
+var (
+	playgroundEditorStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(primaryColor).
+				Padding(0, 1)
+
+	playgroundOutputStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(subtleColor).
+				Padding(0, 1)
+
+	cursorStyle = lipgloss.NewStyle().
+			Reverse(true)
+)
+
+// playground is a CCL editor that shows the mock's output for the text as it is typed
+type playground struct {
+	text   []rune
+	cursor int // Rune offset into text
+	width  int
+	height int
+	saveTo string // Source file that saved tests are appended to
+
+	naming  bool // Prompting for the name of the test to save
+	name    string
+	status  string // Outcome of the last save
+	failed  bool   // The last save failed
+	closed  bool   // The user left the playground
+	mock    *implementation.Mock
+	results []implementation.Result
+}
+
+// playgroundSavePath returns where tests are saved from a playground opened near dir
+func playgroundSavePath(dir string) string {
+	if playgroundFile != "" {
+		return playgroundFile
+	}
+	return filepath.Join(corpusRoot(dir), defaultPlaygroundFile)
+}
+
+func newPlayground(input, saveTo string, width, height int) *playground {
+	p := &playground{
+		text:   []rune(input),
+		width:  width,
+		height: height,
+		saveTo: saveTo,
+		mock:   implementation.NewMock(),
+	}
+	p.cursor = len(p.text)
+	p.evaluate()
+	return p
+}
+
+// evaluate runs every playground function over the current text
+func (p *playground) evaluate() {
+	p.results = make([]implementation.Result, len(playgroundFunctions))
+	for i, function := range playgroundFunctions {
+		result, err := p.mock.Run(function, []string{string(p.text)}, nil)
+		if err != nil {
+			result = implementation.Result{Error: err.Error()}
+		}
+		p.results[i] = result
+	}
+}
+
+// update handles a key press. It returns tea.Quit only for ctrl+c.
+func (p *playground) update(msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyCtrlC {
+		return tea.Quit
+	}
+	if p.naming {
+		p.updateName(msg)
+		return nil
+	}
+
+	edited := true
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.closed = true
+		return nil
+	case tea.KeyCtrlS:
+		p.naming = true
+		p.status = ""
+		return nil
+	case tea.KeyEnter:
+		p.insert("\n")
+	case tea.KeyTab:
+		p.insert("\t")
+	case tea.KeySpace:
+		p.insert(" ")
+	case tea.KeyRunes:
+		p.insert(string(msg.Runes))
+	case tea.KeyBackspace:
+		if p.cursor > 0 {
+			p.text = append(p.text[:p.cursor-1], p.text[p.cursor:]...)
+			p.cursor--
+		}
+	case tea.KeyDelete:
+		if p.cursor < len(p.text) {
+			p.text = append(p.text[:p.cursor], p.text[p.cursor+1:]...)
+		}
+	default:
+		edited = false
+		p.move(msg.Type)
+	}
+
+	if edited {
+		p.evaluate()
+	}
+	return nil
+}
+
+// updateName handles keys while the test name is being entered
+func (p *playground) updateName(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.naming = false
+	case tea.KeyEnter:
+		p.naming = false
+		if err := p.save(p.name); err != nil {
+			p.status, p.failed = err.Error(), true
+		} else {
+			p.status, p.failed = fmt.Sprintf("Saved %s to %s", p.name, p.saveTo), false
+		}
+	case tea.KeyBackspace:
+		if runes := []rune(p.name); len(runes) > 0 {
+			p.name = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		p.name += string(msg.Runes)
+	}
+}
+
+func (p *playground) insert(s string) {
+	inserted := []rune(s)
+	text := make([]rune, 0, len(p.text)+len(inserted))
+	text = append(text, p.text[:p.cursor]...)
+	text = append(text, inserted...)
+	p.text = append(text, p.text[p.cursor:]...)
+	p.cursor += len(inserted)
+}
+
+// move handles cursor movement keys
+func (p *playground) move(key tea.KeyType) {
+	lineStart := p.cursor
+	for lineStart > 0 && p.text[lineStart-1] != '\n' {
+		lineStart--
+	}
+	lineEnd := p.cursor
+	for lineEnd < len(p.text) && p.text[lineEnd] != '\n' {
+		lineEnd++
+	}
+	column := p.cursor - lineStart
+
+	switch key {
+	case tea.KeyLeft:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case tea.KeyRight:
+		if p.cursor < len(p.text) {
+			p.cursor++
+		}
+	case tea.KeyHome, tea.KeyCtrlA:
+		p.cursor = lineStart
+	case tea.KeyEnd, tea.KeyCtrlE:
+		p.cursor = lineEnd
+	case tea.KeyUp:
+		if lineStart == 0 {
+			p.cursor = 0
+			return
+		}
+		prevStart := lineStart - 1
+		for prevStart > 0 && p.text[prevStart-1] != '\n' {
+			prevStart--
+		}
+		p.cursor = min(prevStart+column, lineStart-1)
+	case tea.KeyDown:
+		if lineEnd == len(p.text) {
+			p.cursor = len(p.text)
+			return
+		}
+		nextEnd := lineEnd + 1
+		for nextEnd < len(p.text) && p.text[nextEnd] != '\n' {
+			nextEnd++
+		}
+		p.cursor = min(lineEnd+1+column, nextEnd)
+	}
+}
+
+// save appends the input and the mock's outputs to the playground file as a new source test
+func (p *playground) save(name string) error {
+	if !testNamePattern.MatchString(name) {
+		return fmt.Errorf("test names use lowercase letters, digits and underscores: %q", name)
+	}
+
+	inputs := []string{string(p.text)}
+	test := loader.CompactTest{Name: name, Inputs: inputs}
+	for i, function := range playgroundFunctions {
+		expect, err := implementation.Normalize(p.results[i].Expect())
+		if err != nil {
+			return err
+		}
+		test.Tests = append(test.Tests, loader.CompactValidation{Function: function, Expect: expect})
+	}
+	test.Features = generator.AnalyzeFeatures(inputs, test.Tests[0].Expect, nil).Inferred
+
+	var file *snapshot.File
+	var err error
+	if _, statErr := os.Stat(p.saveTo); statErr == nil {
+		file, err = snapshot.LoadFile(p.saveTo)
+	} else {
+		file, err = newPlaygroundFile(p.saveTo)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Tests without added_in count as part of every corpus version, so the test is
+	// recorded as added in the next minor version and the file raised to it, leaving
+	// it out for implementations pinned to the current corpus
+	if test.AddedIn, err = nextCorpusVersion(file); err != nil {
+		return err
+	}
+	if err := file.SetVersion(test.AddedIn); err != nil {
+		return err
+	}
+	if err := file.AddTest(test); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.saveTo), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(p.saveTo), err)
+	}
+	return file.Write()
+}
+
+// nextCorpusVersion returns the version a new test is added in: the minor version
+// after the embedded corpus, or the file's own version if that is already newer
+func nextCorpusVersion(file *snapshot.File) (string, error) {
+	corpus, err := ccl.CorpusVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine corpus version: %w", err)
+	}
+	current, err := loader.ParseVersion(corpus)
+	if err != nil {
+		return "", err
+	}
+	next := current.NextMinor()
+
+	declared, err := file.Version()
+	if err != nil || declared == "" {
+		return next.String(), err
+	}
+	version, err := loader.ParseVersion(declared)
+	if err != nil {
+		return "", fmt.Errorf("invalid version in %s: %w", file.Path, err)
+	}
+	if version.Compare(next) > 0 {
+		return declared, nil
+	}
+	return next.String(), nil
+}
+
+// newPlaygroundFile starts a source file at path for the current corpus version,
+// pointing $schema at the corpus schema when the file is inside a corpus checkout.
+// save raises the version along with the first test added.
+func newPlaygroundFile(path string) (*snapshot.File, error) {
+	version, err := ccl.CorpusVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine corpus version: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	schema := filepath.Join(corpusRoot(dir), "schemas", "source-format.json")
+	if absDir, err := filepath.Abs(dir); err == nil {
+		if rel, err := filepath.Rel(absDir, schema); err == nil {
+			schema = rel
+		}
+	}
+	return snapshot.NewFile(path, filepath.ToSlash(schema), version)
+}
+
+// view renders the editor above the live outputs, two outputs per row
+func (p *playground) view() string {
+	var content strings.Builder
+	content.WriteString(suiteHeaderStyle.Render("🛝 CCL Playground (mock)") + "\n")
+
+	editorWidth := max(p.width-4, 20)
+	content.WriteString(inputHeaderStyle.Render("📄 CCL INPUT:") + "\n")
+	content.WriteString(playgroundEditorStyle.Width(editorWidth).Render(p.renderText()) + "\n")
+
+	columnWidth := max((p.width-4)/2-2, 20)
+	var boxes []string
+	for i, function := range playgroundFunctions {
+		boxes = append(boxes, playgroundOutputStyle.Width(columnWidth).Render(renderPlaygroundOutput(function, p.results[i], columnWidth-2)))
+	}
+	for i := 0; i < len(boxes); i += 2 {
+		row := boxes[i:min(i+2, len(boxes))]
+		content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, row...) + "\n")
+	}
+
+	switch {
+	case p.naming:
+		content.WriteString(searchStyle.Render(fmt.Sprintf("Save as test named: %s█", p.name)) + "\n")
+		content.WriteString(suiteInfoStyle.Render("enter: save to " + p.saveTo + " • esc: cancel"))
+	default:
+		if p.status != "" {
+			style := successLabelStyle
+			if p.failed {
+				style = errorLabelStyle
+			}
+			content.WriteString(style.Render(p.status) + "\n")
+		}
+		content.WriteString(suiteInfoStyle.Render("type to edit • enter: newline • tab: tab • arrows/home/end: move • ctrl+s: save as source test • esc: close"))
+	}
+	return content.String()
+}
+
+// renderText shows the input with visible whitespace and the cursor
+func (p *playground) renderText() string {
+	var b strings.Builder
+	lineStart := 0
+	for i := 0; i <= len(p.text); i++ {
+		if i < len(p.text) && p.text[i] != '\n' {
+			continue
+		}
+		line := p.text[lineStart:i]
+		if p.cursor >= lineStart && p.cursor <= i {
+			column := p.cursor - lineStart
+			b.WriteString(visualizeWhitespaceInline(string(line[:column])))
+			if column < len(line) {
+				b.WriteString(cursorStyle.Render(visualizeWhitespaceInline(string(line[column]))))
+				b.WriteString(visualizeWhitespaceInline(string(line[column+1:])))
+			} else {
+				b.WriteString(cursorStyle.Render(" "))
+			}
+		} else {
+			b.WriteString(visualizeWhitespaceInline(string(line)))
+		}
+		if i < len(p.text) {
+			b.WriteString("\n")
+		}
+		lineStart = i + 1
+	}
+	return b.String()
+}
+
+// renderPlaygroundOutput shows one function's result, truncated to width
+func renderPlaygroundOutput(function string, result implementation.Result, width int) string {
+	var b strings.Builder
+	if result.Error != "" {
+		b.WriteString(errorHeaderStyle.Render("❌ "+function) + "\n")
+		b.WriteString(fit(result.Error, width))
+		return b.String()
+	}
+
+	b.WriteString(successHeaderStyle.Render("✅ "+function) + "\n")
+	lines := valueLines(result.Value)
+	for i, line := range lines {
+		if i == maxOutputLines {
+			b.WriteString(fmt.Sprintf("... and %d more lines", len(lines)-i))
+			break
+		}
+		b.WriteString(fit(line, width))
+		if i < len(lines)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// playgroundProgram runs the playground on its own, from --playground
+type playgroundProgram struct {
+	p *playground
+}
+
+func (m playgroundProgram) Init() tea.Cmd {
+	return nil
+}
+
+func (m playgroundProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.p.width, m.p.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		cmd := m.p.update(msg)
+		if m.p.closed {
+			return m, tea.Quit
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m playgroundProgram) View() string {
+	return m.p.view()
+}
+
+func runPlayground(saveTo string) {
+	program := tea.NewProgram(playgroundProgram{p: newPlayground("", saveTo, 80, 24)}, tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		fmt.Printf("Error running playground: %v", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxDiffRows is the number of side-by-side diff rows shown for a failing test
+const maxDiffRows = 20
+
+// Result overlay styles
+var (
+	diffExpectedStyle = lipgloss.NewStyle().
+				Foreground(warningColor)
+
+	diffActualStyle = lipgloss.NewStyle().
+			Foreground(errorColor)
+
+	diffSameStyle = lipgloss.NewStyle().
+			Foreground(subtleColor)
+)
+
+// resultSource describes where the TUI gets implementation results from
+type resultSource struct {
+	resultsFile string // Results file written by a conformance run
+	impl        string // Implementation to run: "mock" or an external command line
+}
+
+func (s resultSource) enabled() bool {
+	return s.resultsFile != "" || s.impl != ""
+}
+
+// resultsLoadedMsg carries the implementation results for the loaded tests
+type resultsLoadedMsg struct {
+	implementation string
+	results        map[string]implementation.TestResult
+	err            error
+}
+
+// loadResultsCmd reads the results file, or runs every test against the implementation
+func loadResultsCmd(source resultSource, tests []TestCase) tea.Cmd {
+	return func() tea.Msg {
+		if source.resultsFile != "" {
+			report, err := implementation.LoadReport(source.resultsFile)
+			if err != nil {
+				return resultsLoadedMsg{err: err}
+			}
+			return resultsLoadedMsg{implementation: report.Implementation, results: report.ByName()}
+		}
+
+		impl, err := implementation.Open(source.impl)
+		if err != nil {
+			return resultsLoadedMsg{err: err}
+		}
+		results := make(map[string]implementation.TestResult, len(tests))
+		for _, test := range tests {
+			for _, v := range validations(test) {
+				results[v.test.Name] = implementation.Check(impl, v.test)
+			}
+		}
+		return resultsLoadedMsg{implementation: impl.Name(), results: results}
+	}
+}
+
+// testResults lists the results for a test's validations. Results are keyed by
+// flat test name, so source tests find theirs under their generated names.
+func (m tuiModel) testResults(test TestCase) []implementation.TestResult {
+	var results []implementation.TestResult
+	for _, v := range validations(test) {
+		if result, ok := m.results[v.test.Name]; ok {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// failed reports whether the implementation failed any of a test's validations
+func (m tuiModel) failed(test TestCase) bool {
+	for _, result := range m.testResults(test) {
+		if result.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// jumpToFailure moves to the next (step 1) or previous (step -1) failing test, wrapping around
+func (m *tuiModel) jumpToFailure(step int) {
+	if len(m.tests) == 0 {
+		return
+	}
+	for i := 1; i <= len(m.tests); i++ {
+		index := ((m.currentTest+step*i)%len(m.tests) + len(m.tests)) % len(m.tests)
+		if m.failed(m.tests[index]) {
+			m.currentTest = index
+			m.entryScroll = 0
+			return
+		}
+	}
+}
+
+// resultCounts tallies passing and failing validations across the whole file
+func (m tuiModel) resultCounts() (passed, failed int) {
+	for _, test := range m.allTests {
+		for _, result := range m.testResults(test) {
+			switch {
+			case result.Status == implementation.StatusPass:
+				passed++
+			case result.Failed():
+				failed++
+			}
+		}
+	}
+	return passed, failed
+}
+
+// statusIcon summarizes a test's result, or its expectation when no results are loaded
+// A test with several validations shows its worst result.
+func (m tuiModel) statusIcon(test TestCase) string {
+	results := m.testResults(test)
+	if len(results) == 0 {
+		return "✅"
+	}
+	icon := "🟢"
+	for _, result := range results {
+		switch result.Status {
+		case implementation.StatusFail:
+			return "🔴"
+		case implementation.StatusError:
+			icon = "💥"
+		case implementation.StatusUnsupported:
+			if icon == "🟢" {
+				icon = "⏭️"
+			}
+		}
+	}
+	return icon
+}
+
+// renderResult shows the implementation's outcome for a flat test or one validation
+// of a source test, with a side-by-side
+// diff of the expected and actual values when it failed
+func (m tuiModel) renderResult(test TestCase, compact bool) string {
+	if m.resultsErr != nil {
+		return errorHeaderStyle.Render(fmt.Sprintf("❌ RESULTS UNAVAILABLE: %v", m.resultsErr)) + "\n"
+	}
+	if m.results == nil {
+		if m.resultSource.enabled() {
+			return suiteInfoStyle.Render("⏳ Loading results...") + "\n"
+		}
+		return ""
+	}
+
+	result, ok := m.results[test.Name]
+	if !ok {
+		return metaHeaderStyle.Render(fmt.Sprintf("❔ RESULT: no result from %s", m.implName)) + "\n"
+	}
+
+	var content strings.Builder
+	switch result.Status {
+	case implementation.StatusPass:
+		content.WriteString(successHeaderStyle.Render(fmt.Sprintf("🟢 RESULT: Pass (%s)", m.implName)) + "\n")
+		return content.String()
+	case implementation.StatusUnsupported:
+		content.WriteString(metaHeaderStyle.Render(fmt.Sprintf("⏭️  RESULT: Unsupported (%s)", m.implName)) + "\n")
+		return content.String()
+	case implementation.StatusError:
+		content.WriteString(errorHeaderStyle.Render(fmt.Sprintf("💥 RESULT: Error (%s)", m.implName)) + "\n")
+		content.WriteString(fmt.Sprintf("   %s\n", result.Error))
+		return content.String()
+	}
+
+	content.WriteString(errorHeaderStyle.Render(fmt.Sprintf("🔴 RESULT: Fail (%s)", m.implName)) + "\n")
+	if compact {
+		return content.String()
+	}
+	content.WriteString(m.renderDiff(expectedLines(test), actualLines(result)))
+	return content.String()
+}
+
+// renderDiff lays out expected and actual lines in two columns, highlighting rows that differ
+func (m tuiModel) renderDiff(expected, actual []string) string {
+	width := (m.width - 9) / 2
+	if width < 20 {
+		width = 20
+	}
+
+	var content strings.Builder
+	header := fmt.Sprintf("%s │ %s", padRight("  EXPECTED", width+2), "  ACTUAL")
+	content.WriteString("   " + inputHeaderStyle.Render(header) + "\n")
+
+	rows := alignLines(expected, actual)
+	shown := rows
+	if len(shown) > maxDiffRows {
+		shown = shown[:maxDiffRows]
+	}
+	for _, row := range shown {
+		left := "  " + fit(row.left, width)
+		right := "  " + fit(row.right, width)
+		switch {
+		case row.same:
+			left, right = diffSameStyle.Render(left), diffSameStyle.Render(right)
+		default:
+			if row.hasLeft {
+				left = diffExpectedStyle.Render("- " + fit(row.left, width))
+			}
+			if row.hasRight {
+				right = diffActualStyle.Render("+ " + fit(row.right, width))
+			}
+		}
+		content.WriteString(fmt.Sprintf("   %s │ %s\n", left, right))
+	}
+	if len(rows) > len(shown) {
+		scrollStyle := lipgloss.NewStyle().Foreground(subtleColor)
+		content.WriteString(scrollStyle.Render(fmt.Sprintf("   ... and %d more rows", len(rows)-len(shown))) + "\n")
+	}
+	return content.String()
+}
+
+// expectedLines renders a flat test's expectation one line per entry or JSON line
+func expectedLines(test TestCase) []string {
+	expected, expectError, errorOrEmpty := implementation.FlatExpectation(test)
+	switch {
+	case expectError:
+		return []string{"(error)"}
+	case errorOrEmpty:
+		return []string{"(error or empty)"}
+	}
+	return valueLines(expected)
+}
+
+// actualLines renders an implementation result the same way as expectedLines
+func actualLines(result implementation.TestResult) []string {
+	if result.Error != "" {
+		return []string{"(error) " + strings.ReplaceAll(result.Error, "\n", "↵")}
+	}
+	return valueLines(result.Actual)
+}
+
+// valueLines renders entry lists as "key = value" lines, text line by line and
+// anything else as indented JSON, with whitespace made visible
+func valueLines(value interface{}) []string {
+	if entries, ok := entryList(value); ok {
+		if len(entries) == 0 {
+			return []string{"(no entries)"}
+		}
+		lines := make([]string, len(entries))
+		for i, entry := range entries {
+			lines[i] = fmt.Sprintf("%s = %s", visibleText(entry.Key), visibleText(entry.Value))
+		}
+		return lines
+	}
+
+	if text, ok := value.(string); ok {
+		var lines []string
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, visualizeWhitespaceInline(strings.ReplaceAll(line, "\r", "␍")))
+		}
+		return lines
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return []string{fmt.Sprintf("%v", value)}
+	}
+	return strings.Split(string(data), "\n")
+}
+
+// entryList converts a list of {"key", "value"} objects to entries
+func entryList(value interface{}) ([]Entry, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	entries := make([]Entry, 0, len(items))
+	for _, item := range items {
+		entryMap, ok := item.(map[string]interface{})
+		if !ok || len(entryMap) != 2 {
+			return nil, false
+		}
+		key, keyOK := entryMap["key"].(string)
+		value, valueOK := entryMap["value"].(string)
+		if !keyOK || !valueOK {
+			return nil, false
+		}
+		entries = append(entries, Entry{Key: key, Value: value})
+	}
+	return entries, true
+}
+
+// visibleText shows whitespace and line breaks within a single line
+func visibleText(s string) string {
+	s = strings.ReplaceAll(s, "\r", "␍")
+	s = strings.ReplaceAll(s, "\n", "↵")
+	return visualizeWhitespaceInline(s)
+}
+
+// diffRow is one row of a side-by-side diff
+type diffRow struct {
+	left, right       string
+	hasLeft, hasRight bool
+	same              bool
+}
+
+// alignLines pairs up expected and actual lines using their longest common
+// subsequence. Lines between matches are shown side by side as changed rows.
+func alignLines(left, right []string) []diffRow {
+	// lcs[i][j] is the length of the longest common subsequence of left[i:] and right[j:]
+	lcs := make([][]int, len(left)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(right)+1)
+	}
+	for i := len(left) - 1; i >= 0; i-- {
+		for j := len(right) - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var rows []diffRow
+	var removed, added []string
+	flush := func() {
+		for k := 0; k < len(removed) || k < len(added); k++ {
+			var row diffRow
+			if k < len(removed) {
+				row.left, row.hasLeft = removed[k], true
+			}
+			if k < len(added) {
+				row.right, row.hasRight = added[k], true
+			}
+			rows = append(rows, row)
+		}
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(left) || j < len(right) {
+		switch {
+		case i < len(left) && j < len(right) && left[i] == right[j]:
+			flush()
+			rows = append(rows, diffRow{left: left[i], right: right[j], hasLeft: true, hasRight: true, same: true})
+			i++
+			j++
+		case j == len(right) || (i < len(left) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, left[i])
+			i++
+		default:
+			added = append(added, right[j])
+			j++
+		}
+	}
+	flush()
+	return rows
+}
+
+// fit truncates or pads s to exactly width terminal cells
+func fit(s string, width int) string {
+	if lipgloss.Width(s) > width {
+		runes := []rune(s)
+		for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+			runes = runes[:len(runes)-1]
+		}
+		s = string(runes) + "…"
+	}
+	return padRight(s, width)
+}
+
+func padRight(s string, width int) string {
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/loader"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// traceView shows the source test behind the current test and the flat tests generated from it
+type traceView struct {
+	name    string // Test the trace was opened from
+	trace   *loader.Trace
+	err     error
+	loading bool
+	cursor  int
+}
+
+// traceLoadedMsg carries the trace of a test
+type traceLoadedMsg struct {
+	trace *loader.Trace
+	err   error
+}
+
+// traceCmd traces a source or flat test through the corpus at root
+func traceCmd(root, name string) tea.Cmd {
+	return func() tea.Msg {
+		testLoader := loader.NewTestLoader(root, config.ImplementationConfig{})
+		trace, err := testLoader.Trace(name)
+		return traceLoadedMsg{trace: trace, err: err}
+	}
+}
+
+// traceRoot is the corpus directory holding the current file
+func (m tuiModel) traceRoot() string {
+	if m.corpus {
+		return m.corpusRoot
+	}
+	return corpusRoot(filepath.Dir(m.filename))
+}
+
+// selectTest moves to the named test, clearing filters that hide it
+func (m *tuiModel) selectTest(name string) bool {
+	for pass := 0; pass < 2; pass++ {
+		for i, test := range m.tests {
+			if test.Name == name {
+				m.currentTest = i
+				m.entryScroll = 0
+				return true
+			}
+		}
+		m.filter = testFilter{}
+		m.failuresOnly = false
+		m.applyFilters()
+	}
+	return false
+}
+
+// openTest shows the named test, loading its file first unless it is already open
+func (m tuiModel) openTest(file, name string) (tea.Model, tea.Cmd) {
+	m.trace = nil
+	for _, test := range m.allTests {
+		if test.Name == name {
+			m.selectTest(name)
+			return m, nil
+		}
+	}
+
+	m.corpus = false
+	m.testFiles = nil
+	m.filter = testFilter{}
+	m.failuresOnly = false
+	m.pendingTest = name
+	return m, loadTestFileCmd(file)
+}
+
+// updateTrace handles keys while the trace is open
+func (m tuiModel) updateTrace(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	view := *m.trace
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc", "t":
+		m.trace = nil
+		return m, nil
+	}
+	if view.trace == nil {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		if view.cursor < len(view.trace.Flat)-1 {
+			view.cursor++
+		}
+	case "k", "up":
+		if view.cursor > 0 {
+			view.cursor--
+		}
+	case "enter":
+		if view.cursor < len(view.trace.Flat) {
+			flat := view.trace.Flat[view.cursor]
+			return m.openTest(flat.File, flat.Test.Name)
+		}
+	case "s":
+		return m.openTest(view.trace.SourceFile, view.trace.Source.Name)
+	}
+	m.trace = &view
+	return m, nil
+}
+
+// renderTrace shows the source test with its validations, then every flat test
+// generated from it with the behaviors and conflicts the generator gave it
+func (m tuiModel) renderTrace() string {
+	view := m.trace
+	var content strings.Builder
+	content.WriteString(suiteHeaderStyle.Render("🔎 Trace: "+view.name) + "\n")
+
+	switch {
+	case view.loading:
+		content.WriteString(suiteInfoStyle.Render("⏳ Tracing...") + "\n")
+		return content.String()
+	case view.err != nil:
+		content.WriteString(errorHeaderStyle.Render(fmt.Sprintf("❌ %v", view.err)) + "\n\n")
+		content.WriteString(suiteInfoStyle.Render("esc: close"))
+		return content.String()
+	}
+
+	trace := view.trace
+	content.WriteString(testHeaderStyle.Render("SOURCE TEST: "+trace.Source.Name) + "\n")
+	sourceFile := trace.SourceFile
+	if rel, err := filepath.Rel(m.traceRoot(), sourceFile); err == nil {
+		sourceFile = rel
+	}
+	content.WriteString(sourceFileStyle.Render("📁 "+sourceFile) + "\n")
+	for _, v := range validations(trace.Source) {
+		content.WriteString(renderValidation(v, renderOptions{compact: true}))
+	}
+	content.WriteString(renderMetadata(trace.Source))
+
+	content.WriteString("\n" + testHeaderStyle.Render(fmt.Sprintf("FLAT TESTS (%d):", len(trace.Flat))) + "\n")
+	if len(trace.Flat) == 0 {
+		content.WriteString(metaHeaderStyle.Render("No flat tests were generated from this test; run 'just generate'") + "\n")
+	}
+	for i, flat := range trace.Flat {
+		line := fmt.Sprintf("%s %s (%s)", m.statusIcon(flat.Test), flat.Test.Name, flat.Test.Validation)
+		if i == view.cursor {
+			content.WriteString(selectedFileStyle.Render("► "+line) + "\n")
+		} else {
+			content.WriteString("  " + line + "\n")
+		}
+
+		var details []string
+		if len(flat.Test.Behaviors) > 0 {
+			details = append(details, "behaviors: "+strings.Join(flat.Test.Behaviors, ", "))
+		}
+		if len(flat.DroppedBehaviors) > 0 {
+			details = append(details, "dropped: "+strings.Join(flat.DroppedBehaviors, ", "))
+		}
+		if len(details) > 0 {
+			content.WriteString("     " + tagStyle.Render(strings.Join(details, " • ")) + "\n")
+		}
+		if conflicts := flat.Test.Conflicts.Tags(); len(conflicts) > 0 {
+			line := "conflicts: " + strings.Join(conflicts, ", ")
+			if added := flat.AddedConflicts.Tags(); len(added) > 0 {
+				line += " (added by generator: " + strings.Join(added, ", ") + ")"
+			}
+			content.WriteString("     " + conflictStyle.Render(line) + "\n")
+		}
+	}
+
+	content.WriteString("\n" + suiteInfoStyle.Render("j/k: select • enter: open flat test • s: open source test • esc/t: close"))
+	return content.String()
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/catconflang/ccl-test-data/types"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var treeStyle = lipgloss.NewStyle().
+	Foreground(subtleColor)
+
+// validation is one expectation of a test: the only one of a flat test, or one
+// function of a source test's validation set. Either way it is held in flat form,
+// named and shaped as the generator would write it to generated_tests/.
+type validation struct {
+	test TestCase
+}
+
+func (v validation) function() string {
+	return v.test.Validation
+}
+
+// validations lists a test's expectations in validation set order
+func validations(test TestCase) []validation {
+	set := test.Validations
+	if set == nil {
+		return []validation{{test: test}}
+	}
+
+	fields := []struct {
+		function string
+		value    interface{}
+	}{
+		{"parse", set.Parse},
+		{"parse_indented", set.ParseIndented},
+		{"filter", set.Filter},
+		{"compose", set.Combine},
+		{"expand_dotted", set.ExpandDotted},
+		{"build_hierarchy", set.BuildHierarchy},
+		{"get_string", set.GetString},
+		{"get_int", set.GetInt},
+		{"get_bool", set.GetBool},
+		{"get_float", set.GetFloat},
+		{"get_list", set.GetList},
+		{"pretty_print", set.PrettyPrint},
+		{"round_trip", set.RoundTrip},
+		{"canonical_format", set.Canonical},
+		{"compose_associative", set.ComposeAssociative},
+		{"identity_left", set.IdentityLeft},
+		{"identity_right", set.IdentityRight},
+	}
+
+	var result []validation
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+		flat := test
+		flat.Name = test.Name + "_" + field.function
+		flat.Validations = nil
+		flat.Validation = field.function
+		flat.Functions = []string{field.function}
+		flat.SourceTest = test.Name
+		flat.Expected, flat.Args, flat.ExpectError = validationParts(field.value)
+		result = append(result, validation{test: flat})
+	}
+	return result
+}
+
+// validationParts splits a loaded source validation into its expectation, args
+// and error flag. A null expectation is kept as a count-only expectation, which
+// is how the generator records it.
+func validationParts(value interface{}) (expected interface{}, args []string, expectError bool) {
+	parts, ok := value.(map[string]interface{})
+	if !ok {
+		return value, nil, false
+	}
+
+	expected = parts["expect"]
+	if expected == nil {
+		expected = map[string]interface{}{"count": float64(0)}
+	}
+	switch a := parts["args"].(type) {
+	case []string:
+		args = a
+	case []interface{}:
+		for _, arg := range a {
+			if s, ok := arg.(string); ok {
+				args = append(args, s)
+			}
+		}
+	}
+	expectError, _ = parts["error"].(bool)
+	return expected, args, expectError
+}
+
+// isSourceFile reports whether a test file is in source format, where each test
+// holds a list of validations rather than a single one
+func isSourceFile(data []byte) bool {
+	var file struct {
+		Tests []map[string]interface{} `json:"tests"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil || len(file.Tests) == 0 {
+		return false
+	}
+	_, hasValidations := file.Tests[0]["tests"]
+	return hasValidations
+}
+
+// loadSuite loads a source or flat test file with every test kept
+func loadSuite(filename string) (*TestSuite, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	format := loader.FormatFlat
+	if isSourceFile(data) {
+		format = loader.FormatCompact
+	}
+
+	impl := config.ImplementationConfig{
+		Name:    "test-reader",
+		Version: "1.0.0",
+	}
+	testLoader := loader.NewTestLoader(".", impl)
+	return testLoader.LoadTestFile(filename, loader.LoadOptions{
+		Format:     format,
+		FilterMode: loader.FilterAll, // Load all tests for viewer
+	})
+}
+
+// renderOptions controls how much of a validation is shown
+type renderOptions struct {
+	compact bool // Header line only
+	scroll  int  // First entry, item or line shown
+	static  bool // Non-interactive output, which cannot scroll
+}
+
+// renderInputs shows a test's inputs. Tests with several inputs, such as the
+// algebraic properties, label them a, b, c in the order they are composed.
+func renderInputs(test TestCase) string {
+	var content strings.Builder
+	if len(test.Inputs) <= 1 {
+		inputText := ""
+		if len(test.Inputs) > 0 {
+			inputText = test.Inputs[0]
+		}
+		content.WriteString(inputHeaderStyle.Render("📄 CCL INPUT:") + "\n")
+		content.WriteString(inputContentStyle.Render(formatInputContent(inputText)) + "\n")
+		return content.String()
+	}
+
+	for i, input := range test.Inputs {
+		header := fmt.Sprintf("📄 CCL INPUT %s (%d of %d):", inputLabel(i), i+1, len(test.Inputs))
+		content.WriteString(inputHeaderStyle.Render(header) + "\n")
+		content.WriteString(inputContentStyle.Render(formatInputContent(input)) + "\n")
+	}
+	return content.String()
+}
+
+func inputLabel(i int) string {
+	if i < 26 {
+		return string(rune('a' + i))
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
+
+// renderValidation shows what a validation expects: entries for the parsing
+// functions, a tree for hierarchies, the looked-up value for typed access, text
+// for the formatting functions and the law being checked for algebraic properties
+func renderValidation(v validation, opts renderOptions) string {
+	var content strings.Builder
+	expected, expectError, errorOrEmpty := implementation.FlatExpectation(v.test)
+	call := functionCall(v)
+
+	switch {
+	case expectError:
+		content.WriteString(errorHeaderStyle.Render(fmt.Sprintf("❌ EXPECTED: %s → error", call)) + "\n")
+		return content.String()
+	case errorOrEmpty:
+		content.WriteString(metaHeaderStyle.Render(fmt.Sprintf("⚠️  EXPECTED: %s → error or empty result", call)) + "\n")
+		return content.String()
+	}
+
+	function := v.function()
+	switch {
+	case implementation.ProducesEntries(function):
+		entries, _ := entryList(expected)
+		content.WriteString(successHeaderStyle.Render(fmt.Sprintf("✅ EXPECTED: %s → %s", call, plural(len(entries), "entry", "entries"))) + "\n")
+		if !opts.compact {
+			content.WriteString(renderEntries(entries, opts))
+		}
+
+	case function == "build_hierarchy":
+		content.WriteString(successHeaderStyle.Render(fmt.Sprintf("✅ EXPECTED: %s → object", call)) + "\n")
+		if !opts.compact {
+			content.WriteString(renderLines(treeLines(expected), opts))
+		}
+
+	case function == "get_list":
+		items, _ := expected.([]interface{})
+		content.WriteString(successHeaderStyle.Render(fmt.Sprintf("✅ EXPECTED: %s → %s", call, plural(len(items), "item", "items"))) + "\n")
+		if !opts.compact {
+			lines := make([]string, len(items))
+			for i, item := range items {
+				lines[i] = fmt.Sprintf("[%d] %s", i, scalarText(item))
+			}
+			content.WriteString(renderLines(lines, opts))
+		}
+
+	case strings.HasPrefix(function, "get_"):
+		content.WriteString(successHeaderStyle.Render(fmt.Sprintf("✅ EXPECTED: %s → %s", call, scalarText(expected))) + "\n")
+		if !opts.compact {
+			content.WriteString(fmt.Sprintf("   Type: %s\n", valueType(expected)))
+		}
+
+	case isProperty(function):
+		outcome := "holds"
+		if holds, _ := expected.(bool); !holds {
+			outcome = "does not hold"
+		}
+		content.WriteString(successHeaderStyle.Render(fmt.Sprintf("✅ EXPECTED: %s %s", function, outcome)) + "\n")
+		if !opts.compact {
+			content.WriteString(fmt.Sprintf("   %s\n", propertyLaw(function)))
+		}
+
+	default:
+		// Formatting functions expect text
+		content.WriteString(successHeaderStyle.Render(fmt.Sprintf("✅ EXPECTED: %s → text", call)) + "\n")
+		if !opts.compact {
+			text, _ := expected.(string)
+			content.WriteString(inputContentStyle.Render(formatInputContent(text)) + "\n")
+		}
+	}
+	return content.String()
+}
+
+// functionCall shows a validation as a call, with the args of typed access,
+// e.g. get_int(server.port)
+func functionCall(v validation) string {
+	if len(v.test.Args) == 0 {
+		return v.function()
+	}
+	return fmt.Sprintf("%s(%s)", v.function(), strings.Join(v.test.Args, ", "))
+}
+
+func isProperty(function string) bool {
+	switch function {
+	case "compose_associative", "identity_left", "identity_right":
+		return true
+	}
+	return false
+}
+
+// propertyLaw states the algebraic law a property test checks, in terms of the input labels
+func propertyLaw(function string) string {
+	switch function {
+	case "compose_associative":
+		return "compose(compose(a, b), c) = compose(a, compose(b, c))"
+	case "identity_left":
+		return "compose(empty, a) = a"
+	case "identity_right":
+		return "compose(a, empty) = a"
+	}
+	return ""
+}
+
+// scrollLength counts the rows of a validation that scroll together
+func scrollLength(v validation) int {
+	expected, expectError, errorOrEmpty := implementation.FlatExpectation(v.test)
+	if expectError || errorOrEmpty {
+		return 0
+	}
+	switch function := v.function(); {
+	case implementation.ProducesEntries(function):
+		entries, _ := entryList(expected)
+		return len(entries)
+	case function == "build_hierarchy":
+		return len(treeLines(expected))
+	case function == "get_list":
+		items, _ := expected.([]interface{})
+		return len(items)
+	}
+	return 0
+}
+
+// scrollWindow picks the rows shown from total, starting at the scroll offset
+func scrollWindow(total int, opts renderOptions) (start, end int) {
+	if !opts.static {
+		start = min(opts.scroll, max(total-1, 0))
+	}
+	end = min(start+maxEntriesDisplay, total)
+	return start, end
+}
+
+// renderScrollHints notes rows hidden above and below the window
+func renderScrollHints(start, end, total int, noun string, opts renderOptions, above bool) string {
+	scrollStyle := lipgloss.NewStyle().Foreground(subtleColor)
+	switch {
+	case above && start > 0:
+		return scrollStyle.Render(fmt.Sprintf("   ↑ More %s above (h/← to scroll up)", noun)) + "\n"
+	case !above && end < total && opts.static:
+		return scrollStyle.Render(fmt.Sprintf("   ... and %d more %s (use TUI mode for scrolling)", total-end, noun)) + "\n"
+	case !above && end < total:
+		return scrollStyle.Render(fmt.Sprintf("   ↓ %d more %s below (l/→ to scroll down)", total-end, noun)) + "\n"
+	}
+	return ""
+}
+
+// renderEntries shows entries in boxes, with key and value on one line unless
+// the value spans several
+func renderEntries(entries []Entry, opts renderOptions) string {
+	if len(entries) == 0 {
+		return "   " + emptyValueStyle.Render("(no entries)") + "\n"
+	}
+
+	var content strings.Builder
+	start, end := scrollWindow(len(entries), opts)
+	content.WriteString(renderScrollHints(start, end, len(entries), "entries", opts, true))
+	for _, entry := range entries[start:end] {
+		var entryContent string
+		if strings.Contains(entry.Value, "\n") {
+			keyLine := fmt.Sprintf("%s %s", formatKey(entry.Key), entryEqualsStyle.Render("="))
+			entryContent = fmt.Sprintf("%s\n%s", keyLine, formatValue(entry.Value))
+		} else {
+			entryContent = fmt.Sprintf("%s %s %s", formatKey(entry.Key), entryEqualsStyle.Render("="), formatValue(entry.Value))
+		}
+		content.WriteString(entryBoxStyle.Render(entryContent) + "\n")
+	}
+	content.WriteString(renderScrollHints(start, end, len(entries), "entries", opts, false))
+	return content.String()
+}
+
+// renderLines shows pre-rendered rows of a tree or list
+func renderLines(lines []string, opts renderOptions) string {
+	if len(lines) == 0 {
+		return "   " + emptyValueStyle.Render("(empty)") + "\n"
+	}
+
+	var content strings.Builder
+	start, end := scrollWindow(len(lines), opts)
+	content.WriteString(renderScrollHints(start, end, len(lines), "lines", opts, true))
+	for _, line := range lines[start:end] {
+		content.WriteString("   " + line + "\n")
+	}
+	content.WriteString(renderScrollHints(start, end, len(lines), "lines", opts, false))
+	return content.String()
+}
+
+// treeLines draws a hierarchy with box-drawing branches. Objects are shown with
+// sorted keys, lists with their indexes and strings with whitespace made visible.
+func treeLines(value interface{}) []string {
+	var lines []string
+	var walk func(value interface{}, prefix string)
+	walk = func(value interface{}, prefix string) {
+		var labels []string
+		var children []interface{}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				labels = append(labels, formatKey(key))
+				children = append(children, v[key])
+			}
+		case []interface{}:
+			for i, item := range v {
+				labels = append(labels, treeStyle.Render(fmt.Sprintf("[%d]", i)))
+				children = append(children, item)
+			}
+		}
+
+		for i, child := range children {
+			branch, indent := "├─ ", "│  "
+			if i == len(children)-1 {
+				branch, indent = "└─ ", "   "
+			}
+			line := prefix + treeStyle.Render(branch) + labels[i]
+			switch c := child.(type) {
+			case map[string]interface{}:
+				if len(c) == 0 {
+					line += " " + emptyValueStyle.Render("(empty object)")
+				}
+				lines = append(lines, line)
+				walk(c, prefix+treeStyle.Render(indent))
+			case []interface{}:
+				if len(c) == 0 {
+					line += " " + emptyValueStyle.Render("(empty list)")
+				}
+				lines = append(lines, line)
+				walk(c, prefix+treeStyle.Render(indent))
+			default:
+				lines = append(lines, fmt.Sprintf("%s %s %s", line, entryEqualsStyle.Render("="), formatValue(scalarText(c))))
+			}
+		}
+	}
+
+	if object, ok := value.(map[string]interface{}); ok && len(object) == 0 {
+		return []string{emptyValueStyle.Render("(empty object)")}
+	}
+	walk(value, "")
+	return lines
+}
+
+// scalarText shows a typed value the way it appears in CCL, with whitespace
+// and line breaks made visible in strings
+func scalarText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return visibleText(v)
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// valueType names the JSON type of an expected typed-access value
+func valueType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// renderConflicts shows the functions, behaviors, variants and features a test
+// cannot be run together with
+func renderConflicts(conflicts *types.ConflictSet) string {
+	tags := conflicts.Tags()
+	if len(tags) == 0 {
+		return ""
+	}
+	for i, tag := range tags {
+		tags[i] = conflictStyle.Render(tag)
+	}
+	return metaHeaderStyle.Render("⚔️  CONFLICTS:") + "\n   " + strings.Join(tags, ", ") + "\n"
+}
+
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
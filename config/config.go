@@ -22,6 +22,13 @@ type ImplementationConfig struct {
 	// Explicit exclusions (optional)
 	UnsupportedFeatures  []CCLFeature  `json:"unsupported_features,omitempty"`
 	UnsupportedFunctions []CCLFunction `json:"unsupported_functions,omitempty"`
+
+	// Test tiers to run in addition to core (optional)
+	IncludedTiers []CCLTier `json:"included_tiers,omitempty"`
+
+	// Test corpus version the implementation is validated against (optional).
+	// Tests added in later versions are not loaded.
+	CorpusVersion string `json:"corpus_version,omitempty"`
 }
 
 // CCLFunction represents type-safe CCL function identifiers
@@ -130,6 +137,22 @@ func AllVariants() []CCLVariant {
 	}
 }
 
+// CCLTier represents the source_tests subdirectory a test comes from
+type CCLTier string
+
+const (
+	TierCore         CCLTier = "core"
+	TierExperimental CCLTier = "experimental"
+)
+
+// AllTiers returns all valid test tiers
+func AllTiers() []CCLTier {
+	return []CCLTier{
+		TierCore,
+		TierExperimental,
+	}
+}
+
 // IsValid validates the implementation configuration
 func (c ImplementationConfig) IsValid() error {
 	// Validate behavior choices don't conflict
@@ -207,3 +230,17 @@ func (c ImplementationConfig) HasBehavior(behavior CCLBehavior) bool {
 func (c ImplementationConfig) HasVariant(variant CCLVariant) bool {
 	return c.VariantChoice == variant
 }
+
+// HasTier checks if implementation runs tests from a tier. Core tests, and tests
+// without a tier, are always included.
+func (c ImplementationConfig) HasTier(tier CCLTier) bool {
+	if tier == "" || tier == TierCore {
+		return true
+	}
+	for _, included := range c.IncludedTiers {
+		if included == tier {
+			return true
+		}
+	}
+	return false
+}
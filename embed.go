@@ -0,0 +1,10 @@
+package ccl_test_data
+
+import "embed"
+
+// Corpus holds the source_tests and generated_tests directories of this module
+// version, so the tests can be loaded after go get without cloning the repository.
+// It is embedded here because go:embed cannot reach a parent directory.
+//
+//go:embed source_tests generated_tests
+var Corpus embed.FS
@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/catconflang/ccl-test-data/config"
+)
+
+// FeatureInferenceMode controls how features inferred from test content are applied
+type FeatureInferenceMode int
+
+const (
+	FeatureInferenceOff  FeatureInferenceMode = iota // Do not analyze test content
+	FeatureInferenceWarn                             // Warn about inferred features missing from the test
+	FeatureInferenceAdd                              // Add inferred features to the flat output
+)
+
+// ParseFeatureInferenceMode converts a CLI value (off, warn, add) to a FeatureInferenceMode
+func ParseFeatureInferenceMode(mode string) (FeatureInferenceMode, bool) {
+	switch strings.ToLower(mode) {
+	case "", "off":
+		return FeatureInferenceOff, true
+	case "warn":
+		return FeatureInferenceWarn, true
+	case "add":
+		return FeatureInferenceAdd, true
+	}
+	return FeatureInferenceOff, false
+}
+
+// FeatureAnalysis contains the features inferred from a test's inputs and expected values
+type FeatureAnalysis struct {
+	Inferred []string // All features the content requires, in config.AllFeatures order
+	Missing  []string // Inferred features that were not declared on the test
+}
+
+// InferableFeatures are the features AnalyzeFeatures can detect from test content.
+// Content never shows that a test does not need any other feature.
+var InferableFeatures = []config.CCLFeature{
+	config.FeatureUnicode,
+	config.FeatureComments,
+	config.FeatureEmptyKeys,
+	config.FeatureMultiline,
+}
+
+// AnalyzeFeatures scans CCL inputs and an expected value for content that requires
+// optional language features, and reports which of them are not declared.
+//
+// Only features that can be detected reliably from the text are inferred:
+//   - unicode: any non-ASCII character in inputs or expected strings
+//   - comments: a line starting with "/=" or an expected entry with key "/"
+//   - empty_keys: a line starting with "=" or an expected entry with an empty key
+//   - multiline: an indented continuation line that is plain text rather than a nested entry
+//   - whitespace: tabs, carriage returns or trailing whitespace in the input
+func AnalyzeFeatures(inputs []string, expected interface{}, declared []string) FeatureAnalysis {
+	found := make(map[config.CCLFeature]bool)
+
+	for _, input := range inputs {
+		analyzeInputFeatures(input, found)
+	}
+	analyzeExpectedFeatures(expected, found)
+
+	declaredSet := make(map[string]bool, len(declared))
+	for _, feature := range declared {
+		declaredSet[feature] = true
+	}
+
+	analysis := FeatureAnalysis{
+		Inferred: make([]string, 0),
+		Missing:  make([]string, 0),
+	}
+	for _, feature := range config.AllFeatures() {
+		if !found[feature] {
+			continue
+		}
+		analysis.Inferred = append(analysis.Inferred, string(feature))
+		if !declaredSet[string(feature)] {
+			analysis.Missing = append(analysis.Missing, string(feature))
+		}
+	}
+
+	return analysis
+}
+
+// analyzeInputFeatures records features required to parse a single CCL input
+func analyzeInputFeatures(input string, found map[config.CCLFeature]bool) {
+	if hasNonASCII(input) {
+		found[config.FeatureUnicode] = true
+	}
+	if strings.ContainsAny(input, "\t\r") {
+		found[config.FeatureWhitespace] = true
+	}
+
+	normalized := strings.ReplaceAll(input, "\r\n", "\n")
+	baseline := -1 // Indentation of the first non-blank line
+	for _, line := range strings.Split(normalized, "\n") {
+		trimmed := strings.Trim(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "/=") {
+			found[config.FeatureComments] = true
+		} else if strings.HasPrefix(trimmed, "=") {
+			found[config.FeatureEmptyKeys] = true
+		}
+
+		if strings.TrimRight(line, " \t") != line {
+			found[config.FeatureWhitespace] = true
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if baseline < 0 {
+			baseline = indent
+			continue
+		}
+		// Plain text indented under an entry is a continuation of that entry's value
+		if indent > baseline && !strings.Contains(trimmed, "=") {
+			found[config.FeatureMultiline] = true
+		}
+	}
+}
+
+// analyzeExpectedFeatures records features implied by an expected validation value
+func analyzeExpectedFeatures(expected interface{}, found map[config.CCLFeature]bool) {
+	switch v := expected.(type) {
+	case string:
+		if hasNonASCII(v) {
+			found[config.FeatureUnicode] = true
+		}
+	case []interface{}:
+		for _, item := range v {
+			if entry, ok := item.(map[string]interface{}); ok {
+				key, hasKey := entry["key"].(string)
+				_, hasValue := entry["value"].(string)
+				if hasKey && hasValue {
+					switch key {
+					case "":
+						found[config.FeatureEmptyKeys] = true
+					case "/":
+						found[config.FeatureComments] = true
+					}
+				}
+			}
+			analyzeExpectedFeatures(item, found)
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			switch {
+			case key == "":
+				found[config.FeatureEmptyKeys] = true
+			case key == "/":
+				found[config.FeatureComments] = true
+			case hasNonASCII(key):
+				found[config.FeatureUnicode] = true
+			}
+			analyzeExpectedFeatures(value, found)
+		}
+	}
+}
+
+// hasNonASCII reports whether s contains any character outside the ASCII range
+func hasNonASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
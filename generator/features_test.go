@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestAnalyzeFeatures_InfersFromInputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"plain", "key = value\nother = 1", []string{}},
+		{"comments", "/= note\nkey = value", []string{"comments"}},
+		{"empty_keys", "items =\n  = one\n  = two", []string{"empty_keys"}},
+		{"multiline", "description = First line\n  Second line", []string{"multiline"}},
+		{"nested is not multiline", "config =\n  host = localhost", []string{}},
+		{"unicode", "name = café", []string{"unicode"}},
+		{"tabs", "key = \tvalue", []string{"whitespace"}},
+		{"crlf", "key = value\r\nother = 1", []string{"whitespace"}},
+		{"trailing spaces", "key = value  ", []string{"whitespace"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := AnalyzeFeatures([]string{tt.input}, nil, nil)
+			if !reflect.DeepEqual(analysis.Inferred, tt.expected) {
+				t.Errorf("Expected inferred features %v, got %v", tt.expected, analysis.Inferred)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFeatures_InfersFromExpected(t *testing.T) {
+	expected := []interface{}{
+		map[string]interface{}{"key": "", "value": "item"},
+		map[string]interface{}{"key": "/", "value": "comment"},
+	}
+
+	analysis := AnalyzeFeatures([]string{"x = y"}, expected, nil)
+	want := []string{"comments", "empty_keys"}
+	if !reflect.DeepEqual(analysis.Inferred, want) {
+		t.Errorf("Expected inferred features %v, got %v", want, analysis.Inferred)
+	}
+}
+
+func TestAnalyzeFeatures_ReportsOnlyMissing(t *testing.T) {
+	analysis := AnalyzeFeatures([]string{"/= note\nname = café"}, nil, []string{"comments"})
+
+	if !reflect.DeepEqual(analysis.Missing, []string{"unicode"}) {
+		t.Errorf("Expected missing features [unicode], got %v", analysis.Missing)
+	}
+}
+
+func TestTransformSourceToFlat_FeatureInferenceAdd(t *testing.T) {
+	fg := NewFlatGenerator("", "", GenerateOptions{FeatureInference: FeatureInferenceAdd})
+
+	sourceTest := types.TestCase{
+		Name:   "tab_value",
+		Inputs: []string{"key = \tvalue"},
+		Validations: &types.ValidationSet{
+			Parse: map[string]interface{}{
+				"expect": []interface{}{map[string]interface{}{"key": "key", "value": "value"}},
+			},
+		},
+	}
+
+	flatTests, err := fg.TransformSourceToFlat(sourceTest)
+	if err != nil {
+		t.Fatalf("TransformSourceToFlat failed: %v", err)
+	}
+	if len(flatTests) != 1 {
+		t.Fatalf("Expected 1 flat test, got %d", len(flatTests))
+	}
+	if !reflect.DeepEqual(flatTests[0].Features, []string{"whitespace"}) {
+		t.Errorf("Expected features [whitespace], got %v", flatTests[0].Features)
+	}
+}
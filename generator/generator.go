@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/internal/implementation"
 	"github.com/catconflang/ccl-test-data/loader"
 	"github.com/catconflang/ccl-test-data/types"
 	"github.com/catconflang/ccl-test-data/types/generated"
@@ -32,14 +33,16 @@ type FlatGenerator struct {
 
 // GenerateOptions controls flat format generation behavior
 type GenerateOptions struct {
-	SkipPropertyTests     bool                 // Skip property-*.json files
-	SkipFunctions         []config.CCLFunction // Skip specific functions
-	OnlyFunctions         []config.CCLFunction // Generate only these functions
-	SourceFormat          loader.TestFormat    // Input format (compact or flat)
-	Verbose               bool                 // Enable verbose output
-	SchemasDir            string               // Path to schemas directory (for behavior metadata)
-	AutoGenerateConflicts bool                 // Auto-generate conflicts from behavior metadata
-	ValidateSourceTests   bool                 // Validate source tests against metadata
+	SkipPropertyTests     bool                    // Skip property-*.json files
+	SkipFunctions         []config.CCLFunction    // Skip specific functions
+	OnlyFunctions         []config.CCLFunction    // Generate only these functions
+	SourceFormat          loader.TestFormat       // Input format (compact or flat)
+	Verbose               bool                    // Enable verbose output
+	SchemasDir            string                  // Path to schemas directory (for behavior metadata)
+	AutoGenerateConflicts bool                    // Auto-generate conflicts from behavior metadata
+	ValidateSourceTests   bool                    // Validate source tests against metadata
+	FeatureInference      FeatureInferenceMode    // Warn about or add features inferred from test content
+	Discovery             loader.DiscoveryOptions // Include and exclude patterns for source files
 }
 
 // NewFlatGenerator creates a new flat format generator
@@ -69,19 +72,22 @@ func NewFlatGenerator(sourceDir, outputDir string, opts GenerateOptions) *FlatGe
 	return fg
 }
 
-// GenerateAll processes all source test files and generates flat format
+// GenerateAll processes all source test files under SourceDir, including those in
+// subdirectories, and generates flat format files in OutputDir
 func (fg *FlatGenerator) GenerateAll() error {
 	if err := os.MkdirAll(fg.OutputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	pattern := filepath.Join(fg.SourceDir, "*.json")
-	files, err := filepath.Glob(pattern)
+	sourceFiles, err := loader.FindTestFiles(os.DirFS(fg.SourceDir), ".", fg.Options.Discovery)
 	if err != nil {
 		return fmt.Errorf("failed to find source files: %w", err)
 	}
 
-	for _, file := range files {
+	// Output is flat, so files in different tiers must not share a name
+	outputs := make(map[string]string)
+	for _, sourceFile := range sourceFiles {
+		file := filepath.Join(fg.SourceDir, filepath.FromSlash(sourceFile))
 		basename := filepath.Base(file)
 
 		// Skip property tests if requested
@@ -92,6 +98,11 @@ func (fg *FlatGenerator) GenerateAll() error {
 			continue
 		}
 
+		if previous, ok := outputs[basename]; ok {
+			return fmt.Errorf("source files %s and %s would both generate %s", previous, file, basename)
+		}
+		outputs[basename] = file
+
 		if err := fg.GenerateFile(file); err != nil {
 			return fmt.Errorf("failed to generate %s: %w", file, err)
 		}
@@ -163,6 +174,9 @@ func (fg *FlatGenerator) GenerateFile(sourceFile string) error {
 		Schema: "http://json-schema.org/draft-07/schema#",
 		Tests:  flatTests,
 	}
+	if sourceSuite.Version != "" {
+		wrapper.Version = &sourceSuite.Version
+	}
 
 	// Write flat format file
 	outputFile := filepath.Join(fg.OutputDir, filepath.Base(sourceFile))
@@ -216,6 +230,9 @@ func (fg *FlatGenerator) TransformSourceToFlat(sourceTest types.TestCase) ([]typ
 			ExpectError: validationComponents.Error,
 			Meta:        sourceTest.Meta,
 			SourceTest:  sourceTest.Name,
+			Tier:        sourceTest.Tier,
+			AddedIn:     sourceTest.AddedIn,
+			ChangedIn:   sourceTest.ChangedIn,
 		}
 
 		// Extract and populate type-safe metadata
@@ -241,6 +258,18 @@ func (fg *FlatGenerator) TransformSourceToFlat(sourceTest types.TestCase) ([]typ
 		}
 		flatTest.Features = uniqueFeatures
 
+		// Check declared features against those required by the test content
+		if fg.Options.FeatureInference != FeatureInferenceOff {
+			analysis := AnalyzeFeatures(flatTest.Inputs, flatTest.Expected, flatTest.Features)
+			if len(analysis.Missing) > 0 {
+				if fg.Options.FeatureInference == FeatureInferenceAdd {
+					flatTest.Features = append(flatTest.Features, analysis.Missing...)
+				} else {
+					fmt.Printf("Warning [%s]: test content requires undeclared features %v\n", flatTest.Name, analysis.Missing)
+				}
+			}
+		}
+
 		// Filter behaviors to only those affecting this function (if metadata available)
 		if fg.BehaviorMetadata != nil {
 			flatTest.Behaviors = fg.BehaviorMetadata.FilterBehaviorsForFunction(sourceTest.Behaviors, validationName)
@@ -430,6 +459,13 @@ func (fg *FlatGenerator) convertToFlatFormat(test types.TestCase) generated.Gene
 		Conflicts:  conflicts,
 		Args:       fg.getArgsForValidation(test.Validation, test.Args),
 		SourceTest: &test.SourceTest,
+		ChangedIn:  test.ChangedIn,
+	}
+	if test.Tier != "" {
+		flatTest.Tier = &test.Tier
+	}
+	if test.AddedIn != "" {
+		flatTest.AddedIn = &test.AddedIn
 	}
 
 	return flatTest
@@ -459,8 +495,8 @@ func (fg *FlatGenerator) getArgsForValidation(validation string, args []string)
 func (fg *FlatGenerator) createExpectedStructure(validation string, data interface{}) generated.GeneratedFormatSimpleJsonTestsElemExpected {
 	expected := generated.GeneratedFormatSimpleJsonTestsElemExpected{}
 
-	switch validation {
-	case "parse", "parse_indented", "filter", "compose", "expand_dotted":
+	switch {
+	case implementation.ProducesEntries(validation):
 		// These validations expect entries (key-value pairs)
 		if entries, ok := data.([]interface{}); ok {
 			expected.Count = len(entries)
@@ -479,15 +515,15 @@ func (fg *FlatGenerator) createExpectedStructure(validation string, data interfa
 			}
 			expected.Entries = entryList
 		}
-	case "build_hierarchy":
+	case validation == "build_hierarchy":
 		// Hierarchy expects an object
 		expected.Count = 1
 		expected.Object = data
-	case "get_string", "get_int", "get_bool", "get_float":
+	case validation == "get_string", validation == "get_int", validation == "get_bool", validation == "get_float":
 		// Typed access expects a single value
 		expected.Count = 1
 		expected.Value = data
-	case "get_list":
+	case validation == "get_list":
 		// List access expects a list
 		if list, ok := data.([]interface{}); ok {
 			expected.Count = len(list)
@@ -549,13 +585,22 @@ func (fg *FlatGenerator) convertConflicts(conflicts *types.ConflictSet) *generat
 
 // Helper functions
 
+// flatValidationNames maps ValidationSet fields whose JSON name differs from the
+// flat format function name
+var flatValidationNames = map[string]string{
+	"combine": "compose",
+}
+
 // getValidationName extracts the validation name from JSON tag or field name
 func getValidationName(fieldType reflect.StructField) string {
 	// Check for JSON tag first
 	if jsonTag := fieldType.Tag.Get("json"); jsonTag != "" {
 		// Remove ",omitempty" suffix if present
 		if idx := strings.Index(jsonTag, ","); idx != -1 {
-			return jsonTag[:idx]
+			jsonTag = jsonTag[:idx]
+		}
+		if name, ok := flatValidationNames[jsonTag]; ok {
+			return name
 		}
 		return jsonTag
 	}
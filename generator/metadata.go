@@ -29,9 +29,21 @@ type BehaviorMetadataDefaults struct {
 	Description      string `json:"description"`
 }
 
-// sourceFormatSchema represents the structure needed to extract x-behaviorMetadata
+// SpecSection is a section of the CCL specification that tests can reference
+type SpecSection struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// specSections represents the x-specSections section in source-format.json
+type specSections struct {
+	Sections []SpecSection `json:"sections"`
+}
+
+// sourceFormatSchema represents the structure needed to extract x-behaviorMetadata and x-specSections
 type sourceFormatSchema struct {
 	BehaviorMetadata *BehaviorMetadata `json:"x-behaviorMetadata"`
+	SpecSections     *specSections     `json:"x-specSections"`
 }
 
 // LoadBehaviorMetadata loads behavior metadata from source-format.json in the schemas directory
@@ -55,6 +67,27 @@ func LoadBehaviorMetadata(schemasDir string) (*BehaviorMetadata, error) {
 	return schema.BehaviorMetadata, nil
 }
 
+// LoadSpecSections loads the specification sections, in order, from source-format.json in the schemas directory
+func LoadSpecSections(schemasDir string) ([]SpecSection, error) {
+	schemaPath := filepath.Join(schemasDir, "source-format.json")
+
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source-format.json: %w", err)
+	}
+
+	var schema sourceFormatSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse source-format.json: %w", err)
+	}
+
+	if schema.SpecSections == nil {
+		return nil, fmt.Errorf("x-specSections section not found in source-format.json")
+	}
+
+	return schema.SpecSections.Sections, nil
+}
+
 // FilterBehaviorsForFunction filters behaviors to only include those that affect
 // the given function, using the behavior metadata mapping.
 func (m *BehaviorMetadata) FilterBehaviorsForFunction(behaviors []string, function string) []string {
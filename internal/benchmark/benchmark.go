@@ -1,16 +1,19 @@
 // Package benchmark provides performance tracking and monitoring for CCL test operations.
 //
 // This package implements benchmark tracking for test generation, statistics collection,
-// and CCL parsing operations. It supports both one-time measurements and historical
+// and CCL implementations, which are run on a corpus of large generated documents. It supports both one-time measurements and historical
 // performance tracking with regression detection.
 //
 // Key Features:
 //   - Test generation performance benchmarks
 //   - Statistics collection timing
+//   - Compile time of generated test packages
+//   - Throughput of CCL implementations (parse, build_hierarchy, typed access, round_trip)
 //   - Memory allocation tracking
 //   - Performance regression detection
 //   - JSON output for CI/CD integration
 //   - Historical performance comparison
+//   - Append-only run history with rolling-median trends
 //
 // Example Usage:
 //
@@ -24,8 +27,12 @@ package benchmark
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"os/exec"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -38,6 +45,17 @@ type BenchmarkResult struct {
 	Timestamp     time.Time     `json:"timestamp"`
 	GitCommit     string        `json:"gitCommit,omitempty"`
 	GoVersion     string        `json:"goVersion"`
+	Samples       []Sample      `json:"samples,omitempty"` // Every measured run when sampled with Measure
+	Bytes         int64         `json:"bytes,omitempty"`   // Input processed per run, for throughput
+}
+
+// Throughput is the input processed per second in MB/s, or 0 when the result
+// has no input size
+func (r *BenchmarkResult) Throughput() float64 {
+	if r.Bytes == 0 || r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Bytes) / 1e6 / r.Duration.Seconds()
 }
 
 // BenchmarkTracker manages performance measurements
@@ -151,43 +169,79 @@ func LoadResults(filepath string) (map[string]*BenchmarkResult, error) {
 	return results, nil
 }
 
-// CompareResults compares current results with historical results and detects regressions
-func CompareResults(current, historical map[string]*BenchmarkResult, thresholdPct float64) []RegressionAlert {
+// CompareResults compares current results with historical results and detects regressions.
+// When both results were sampled repeatedly, a change is only reported if the
+// Mann-Whitney test finds it significant at alpha, so that noise between runs is not
+// flagged; single runs are compared directly. Either way the change in the mean must
+// exceed thresholdPct.
+func CompareResults(current, historical map[string]*BenchmarkResult, thresholdPct, alpha float64) []RegressionAlert {
 	var alerts []RegressionAlert
 
-	for name, currentResult := range current {
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		currentResult := current[name]
 		historicalResult, exists := historical[name]
 		if !exists {
 			continue // No historical data to compare
 		}
-
-		durationChange := float64(currentResult.Duration-historicalResult.Duration) / float64(historicalResult.Duration) * 100
-		memChange := float64(currentResult.MemAllocBytes-historicalResult.MemAllocBytes) / float64(historicalResult.MemAllocBytes) * 100
-
-		if durationChange > thresholdPct {
-			alerts = append(alerts, RegressionAlert{
-				BenchmarkName:   name,
-				Metric:          "duration",
-				ChangePercent:   durationChange,
-				CurrentValue:    currentResult.Duration.String(),
-				HistoricalValue: historicalResult.Duration.String(),
-			})
-		}
-
-		if memChange > thresholdPct {
-			alerts = append(alerts, RegressionAlert{
+		sampled := len(currentResult.Samples) > 1 && len(historicalResult.Samples) > 1
+
+		for _, metric := range []struct {
+			name                string
+			current, historical []float64
+			format              func(float64) string
+		}{
+			{"duration", currentResult.durations(), historicalResult.durations(), func(v float64) string { return time.Duration(v).String() }},
+			{"memory", currentResult.memory(), historicalResult.memory(), func(v float64) string { return fmt.Sprintf("%.0f bytes", v) }},
+		} {
+			currentMean, _ := meanStdDev(metric.current)
+			historicalMean, _ := meanStdDev(metric.historical)
+			change := percentChange(currentMean, historicalMean)
+			if change <= thresholdPct {
+				continue
+			}
+
+			alert := RegressionAlert{
 				BenchmarkName:   name,
-				Metric:          "memory",
-				ChangePercent:   memChange,
-				CurrentValue:    fmt.Sprintf("%d bytes", currentResult.MemAllocBytes),
-				HistoricalValue: fmt.Sprintf("%d bytes", historicalResult.MemAllocBytes),
-			})
+				Metric:          metric.name,
+				ChangePercent:   change,
+				CurrentValue:    metric.format(currentMean),
+				HistoricalValue: metric.format(historicalMean),
+			}
+			if sampled {
+				alert.PValue = MannWhitney(metric.current, metric.historical)
+				if alert.PValue >= alpha {
+					continue
+				}
+			}
+			alerts = append(alerts, alert)
 		}
 	}
 
 	return alerts
 }
 
+// ComparablePValue is the smallest p-value CompareResults can reach for every
+// benchmark sampled repeatedly in both current and historical: the largest MinPValue
+// among them, or 0 when there are none. If it is not below alpha, a regression in at
+// least one of them can never be reported.
+func ComparablePValue(current, historical map[string]*BenchmarkResult) float64 {
+	var largest float64
+	for name, currentResult := range current {
+		historicalResult, exists := historical[name]
+		if !exists || len(currentResult.Samples) <= 1 || len(historicalResult.Samples) <= 1 {
+			continue
+		}
+		largest = math.Max(largest, MinPValue(len(currentResult.Samples), len(historicalResult.Samples)))
+	}
+	return largest
+}
+
 // RegressionAlert represents a performance regression detection
 type RegressionAlert struct {
 	BenchmarkName   string  `json:"benchmarkName"`
@@ -195,22 +249,41 @@ type RegressionAlert struct {
 	ChangePercent   float64 `json:"changePercent"`
 	CurrentValue    string  `json:"currentValue"`
 	HistoricalValue string  `json:"historicalValue"`
+	PValue          float64 `json:"pValue,omitempty"` // Mann-Whitney p-value, when both results were sampled
 }
 
-// getGitCommit attempts to get the current git commit hash
+// getGitCommit returns the commit checked out in the working directory, or an
+// empty string outside a git repository
 func getGitCommit() string {
-	// This would typically run git rev-parse HEAD
-	// For simplicity, returning empty string - can be enhanced
-	return ""
+	output, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
 }
 
 // PrintResults prints benchmark results in a human-readable format
 func PrintResults(results map[string]*BenchmarkResult) {
 	fmt.Printf("🚀 Benchmark Results\n\n")
 
-	for name, result := range results {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		result := results[name]
 		fmt.Printf("📊 %s:\n", name)
-		fmt.Printf("  Duration: %v\n", result.Duration)
+		if summary := result.Summary(); summary.N > 1 {
+			fmt.Printf("  Duration: %v ± %v (95%% CI %v – %v, n=%d)\n",
+				summary.Mean, summary.StdDev, summary.CILow, summary.CIHigh, summary.N)
+		} else {
+			fmt.Printf("  Duration: %v\n", result.Duration)
+		}
+		if throughput := result.Throughput(); throughput > 0 {
+			fmt.Printf("  Throughput: %.2f MB/s (%d bytes per run)\n", throughput, result.Bytes)
+		}
 		fmt.Printf("  Memory Allocated: %d bytes (%d objects)\n", result.MemAllocBytes, result.MemAllocObjs)
 		fmt.Printf("  Timestamp: %s\n", result.Timestamp.Format("2006-01-02 15:04:05"))
 		if result.GitCommit != "" {
@@ -231,7 +304,11 @@ func PrintRegressionAlerts(alerts []RegressionAlert) {
 
 	for _, alert := range alerts {
 		fmt.Printf("🔴 %s (%s):\n", alert.BenchmarkName, alert.Metric)
-		fmt.Printf("  Change: %.1f%%\n", alert.ChangePercent)
+		fmt.Printf("  Change: %.1f%%", alert.ChangePercent)
+		if alert.PValue > 0 {
+			fmt.Printf(" (p=%.3f)", alert.PValue)
+		}
+		fmt.Println()
 		fmt.Printf("  Current: %s\n", alert.CurrentValue)
 		fmt.Printf("  Historical: %s\n\n", alert.HistoricalValue)
 	}
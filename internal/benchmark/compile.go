@@ -0,0 +1,101 @@
+package benchmark
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// TestCompiler builds generated Go test packages in a private build cache, so compile
+// times are not hidden by results cached in earlier builds. Dependencies are built into
+// the cache up front, leaving only the generated packages to compile and link. Vet and
+// cgo are disabled so only the compiler and linker are measured.
+type TestCompiler struct {
+	packages string // Package pattern matching the generated tests
+	workDir  string // Holds the build cache and discarded test binaries
+}
+
+// NewTestCompiler prepares to compile the test packages under dir, which must be
+// inside the current module. Building the dependencies may take a minute.
+func NewTestCompiler(dir string) (*TestCompiler, error) {
+	pattern := filepath.ToSlash(filepath.Clean(dir)) + "/..."
+	if !filepath.IsAbs(dir) {
+		pattern = "./" + pattern
+	}
+
+	workDir, err := os.MkdirTemp("", "ccl-compile-benchmark-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build directory: %w", err)
+	}
+	c := &TestCompiler{packages: pattern, workDir: workDir}
+
+	deps, err := c.dependencies()
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	if _, err := c.goCommand(append([]string{"build"}, deps...)...); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to build test dependencies: %w", err)
+	}
+
+	return c, nil
+}
+
+// Compile compiles and links a test binary for every generated package
+func (c *TestCompiler) Compile() error {
+	binDir := filepath.Join(c.workDir, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return fmt.Errorf("failed to create binary directory: %w", err)
+	}
+	_, err := c.goCommand("test", "-vet=off", "-c", "-o", binDir+string(filepath.Separator), c.packages)
+	return err
+}
+
+// Close removes the private build cache
+func (c *TestCompiler) Close() error {
+	return os.RemoveAll(c.workDir)
+}
+
+// dependencies lists the packages imported by the generated tests, excluding the
+// generated packages themselves and the test main packages
+func (c *TestCompiler) dependencies() ([]string, error) {
+	output, err := c.goCommand("list", c.packages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list test packages: %w", err)
+	}
+	generated := make(map[string]bool)
+	for _, pkg := range strings.Fields(string(output)) {
+		generated[pkg] = true
+	}
+
+	output, err = c.goCommand("list", "-deps", "-test",
+		"-f", `{{if and (not .ForTest) (ne .Name "main")}}{{.ImportPath}}{{end}}`, c.packages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list test dependencies: %w", err)
+	}
+	var deps []string
+	for _, pkg := range strings.Fields(string(output)) {
+		if !generated[pkg] {
+			deps = append(deps, pkg)
+		}
+	}
+	return deps, nil
+}
+
+// goCommand runs the go tool with the private build cache and returns its standard output
+func (c *TestCompiler) goCommand(args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Env = append(os.Environ(), "GOCACHE="+filepath.Join(c.workDir, "cache"), "CGO_ENABLED=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go %s: %w\n%s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
@@ -0,0 +1,278 @@
+package benchmark
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoryRun is one benchmark run in the history, keyed by commit and time
+type HistoryRun struct {
+	GitCommit string                      `json:"gitCommit,omitempty"`
+	Timestamp time.Time                   `json:"timestamp"`
+	GoVersion string                      `json:"goVersion"`
+	Results   map[string]*BenchmarkResult `json:"results"`
+}
+
+// NewHistoryRun records results as a run, taking its commit and time from the results
+func NewHistoryRun(results map[string]*BenchmarkResult) HistoryRun {
+	run := HistoryRun{Results: results}
+	for _, result := range results {
+		if result.Timestamp.After(run.Timestamp) {
+			run.Timestamp = result.Timestamp
+		}
+		run.GitCommit = result.GitCommit
+		run.GoVersion = result.GoVersion
+	}
+	return run
+}
+
+// AppendHistory adds a run to the history file, one JSON object per line. Earlier
+// runs are never rewritten, so the file can be appended to from CI and merged by
+// concatenation.
+func AppendHistory(path string, run HistoryRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal benchmark run: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create benchmark history directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open benchmark history %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to append to benchmark history %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadHistory reads every run from a history file, oldest first
+func LoadHistory(path string) ([]HistoryRun, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read benchmark history from %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var runs []HistoryRun
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var run HistoryRun
+		if err := json.Unmarshal([]byte(text), &run); err != nil {
+			return nil, fmt.Errorf("%s:%d: failed to unmarshal benchmark run: %w", path, line, err)
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read benchmark history from %s: %w", path, err)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Timestamp.Before(runs[j].Timestamp)
+	})
+	return runs, nil
+}
+
+// TrendPoint is one run of a benchmark, compared with the median of the runs before it
+type TrendPoint struct {
+	GitCommit      string        `json:"gitCommit,omitempty"`
+	Timestamp      time.Time     `json:"timestamp"`
+	Duration       time.Duration `json:"duration"`
+	MemAllocBytes  int64         `json:"memAllocBytes"`
+	DurationChange float64       `json:"durationChangePercent"` // Against the rolling median, 0 for the first run
+	MemoryChange   float64       `json:"memoryChangePercent"`
+	Regression     bool          `json:"regression"`
+}
+
+// Trend is the history of one benchmark
+type Trend struct {
+	Name           string        `json:"name"`
+	Points         []TrendPoint  `json:"points"`
+	MedianDuration time.Duration `json:"medianDuration"` // Rolling median the latest run is compared with
+	MedianMemory   int64         `json:"medianMemAllocBytes"`
+}
+
+// Latest is the most recent run of the benchmark
+func (t Trend) Latest() TrendPoint {
+	return t.Points[len(t.Points)-1]
+}
+
+// Regressions are the runs that exceeded the threshold against their rolling median
+func (t Trend) Regressions() []TrendPoint {
+	var regressions []TrendPoint
+	for _, point := range t.Points {
+		if point.Regression {
+			regressions = append(regressions, point)
+		}
+	}
+	return regressions
+}
+
+// AnalyzeHistory builds a trend per benchmark. Each run is compared with the median
+// of up to window runs before it, so that a single noisy run neither hides nor
+// raises a regression. A run regresses when its duration or memory exceeds that
+// median by more than thresholdPct.
+func AnalyzeHistory(runs []HistoryRun, window int, thresholdPct float64) []Trend {
+	if window < 1 {
+		window = 1
+	}
+
+	byName := make(map[string]*Trend)
+	var names []string
+	for _, run := range runs {
+		for name, result := range run.Results {
+			trend, exists := byName[name]
+			if !exists {
+				trend = &Trend{Name: name}
+				byName[name] = trend
+				names = append(names, name)
+			}
+			commit := run.GitCommit
+			if result.GitCommit != "" {
+				commit = result.GitCommit
+			}
+			trend.Points = append(trend.Points, TrendPoint{
+				GitCommit:     commit,
+				Timestamp:     run.Timestamp,
+				Duration:      result.Duration,
+				MemAllocBytes: result.MemAllocBytes,
+			})
+		}
+	}
+	sort.Strings(names)
+
+	trends := make([]Trend, 0, len(names))
+	for _, name := range names {
+		trend := byName[name]
+		for i := range trend.Points {
+			previous := trend.Points[max(0, i-window):i]
+			if len(previous) == 0 {
+				continue
+			}
+			medianDuration, medianMemory := rollingMedian(previous)
+			point := &trend.Points[i]
+			point.DurationChange = percentChange(float64(point.Duration), float64(medianDuration))
+			point.MemoryChange = percentChange(float64(point.MemAllocBytes), float64(medianMemory))
+			point.Regression = point.DurationChange > thresholdPct || point.MemoryChange > thresholdPct
+		}
+		start := max(0, len(trend.Points)-1-window)
+		if previous := trend.Points[start : len(trend.Points)-1]; len(previous) > 0 {
+			trend.MedianDuration, trend.MedianMemory = rollingMedian(previous)
+		}
+		trends = append(trends, *trend)
+	}
+	return trends
+}
+
+func rollingMedian(points []TrendPoint) (time.Duration, int64) {
+	durations := make([]float64, len(points))
+	memory := make([]float64, len(points))
+	for i, point := range points {
+		durations[i] = float64(point.Duration)
+		memory[i] = float64(point.MemAllocBytes)
+	}
+	return time.Duration(median(durations)), int64(median(memory))
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func percentChange(current, baseline float64) float64 {
+	if baseline == 0 {
+		return 0
+	}
+	return (current - baseline) / baseline * 100
+}
+
+// sparkBlocks are the levels of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of block characters scaled between their
+// minimum and maximum
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(sparkBlocks)-1))
+		}
+		line.WriteRune(sparkBlocks[level])
+	}
+	return line.String()
+}
+
+// PrintTrends prints a sparkline per benchmark and metric with the latest run
+// compared with its rolling median, followed by every run that regressed
+func PrintTrends(trends []Trend, window int) {
+	fmt.Printf("📈 Benchmark History (rolling median of the last %d run(s))\n\n", window)
+
+	for _, trend := range trends {
+		latest := trend.Latest()
+		durations := make([]float64, len(trend.Points))
+		memory := make([]float64, len(trend.Points))
+		for i, point := range trend.Points {
+			durations[i] = float64(point.Duration)
+			memory[i] = float64(point.MemAllocBytes)
+		}
+
+		fmt.Printf("📊 %s (%d runs):\n", trend.Name, len(trend.Points))
+		fmt.Printf("  Duration: %s  %v", Sparkline(durations), latest.Duration)
+		if len(trend.Points) > 1 {
+			fmt.Printf(" (median %v, %+.1f%%)", trend.MedianDuration, latest.DurationChange)
+		}
+		fmt.Println()
+		fmt.Printf("  Memory:   %s  %d bytes", Sparkline(memory), latest.MemAllocBytes)
+		if len(trend.Points) > 1 {
+			fmt.Printf(" (median %d bytes, %+.1f%%)", trend.MedianMemory, latest.MemoryChange)
+		}
+		fmt.Println()
+
+		for _, point := range trend.Regressions() {
+			commit := point.GitCommit
+			if commit == "" {
+				commit = "unknown commit"
+			}
+			fmt.Printf("  🔴 %s %s: duration %+.1f%%, memory %+.1f%%\n",
+				point.Timestamp.Format("2006-01-02 15:04"), shortCommit(commit), point.DurationChange, point.MemoryChange)
+		}
+		fmt.Println()
+	}
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
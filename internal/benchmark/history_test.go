@@ -0,0 +1,60 @@
+package benchmark
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "benchmarks", "history.jsonl")
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// A noisy run in the middle is outvoted by the median; the last run regresses
+	durations := []time.Duration{100, 102, 300, 98, 101, 150}
+	for i, duration := range durations {
+		run := NewHistoryRun(map[string]*BenchmarkResult{
+			"parse": {Name: "parse", Duration: duration * time.Millisecond, MemAllocBytes: 1000,
+				Timestamp: start.Add(time.Duration(i) * time.Hour), GitCommit: string(rune('a' + i))},
+		})
+		if err := AppendHistory(path, run); err != nil {
+			t.Fatalf("AppendHistory() error = %v", err)
+		}
+	}
+
+	runs, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	if len(runs) != len(durations) || runs[0].GitCommit != "a" {
+		t.Fatalf("LoadHistory() = %d runs, first commit %q", len(runs), runs[0].GitCommit)
+	}
+
+	trends := AnalyzeHistory(runs, 3, 10)
+	if len(trends) != 1 {
+		t.Fatalf("AnalyzeHistory() = %d trends, want 1", len(trends))
+	}
+	trend := trends[0]
+	if trend.MedianDuration != 101*time.Millisecond {
+		t.Errorf("MedianDuration = %v, want 101ms", trend.MedianDuration)
+	}
+	var regressed []string
+	for _, point := range trend.Regressions() {
+		regressed = append(regressed, point.GitCommit)
+	}
+	// The spike regresses against its own median, but doesn't hide the last regression
+	if len(regressed) != 2 || regressed[0] != "c" || regressed[1] != "f" {
+		t.Errorf("Regressions() commits = %v, want [c f]", regressed)
+	}
+	if !trend.Latest().Regression {
+		t.Error("latest run is not a regression")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := Sparkline([]float64{1, 2, 3, 8}); got != "▁▂▃█" {
+		t.Errorf("Sparkline() = %q", got)
+	}
+	if got := Sparkline([]float64{5, 5}); got != "▁▁" {
+		t.Errorf("Sparkline() of flat values = %q", got)
+	}
+}
@@ -0,0 +1,204 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+)
+
+// Document is a CCL document in the implementation benchmark corpus
+type Document struct {
+	Name  string
+	Text  string
+	Get   string   // Typed access function benchmarked on the document, e.g. get_int
+	Path  []string // Path the typed access reads
+	Shape string   // What the document stresses, for command output
+}
+
+// Corpus builds the implementation benchmark documents. Scale multiplies their size;
+// at scale 1 each is between 100 and 300 KB. The documents are generated
+// deterministically, so results are comparable between runs.
+func Corpus(scale int) []Document {
+	if scale < 1 {
+		scale = 1
+	}
+	return []Document{
+		deepNesting(scale),
+		wideMap(scale),
+		multilineValues(scale),
+		manyComments(scale),
+		largeLists(scale),
+	}
+}
+
+// deepNesting nests sections 50 levels deep, repeated side by side under distinct
+// top-level keys so that each section stays a single object
+func deepNesting(scale int) Document {
+	const depth = 50
+	var text strings.Builder
+	for section := 0; section < 20*scale; section++ {
+		fmt.Fprintf(&text, "section_%d =\n", section)
+		for level := 0; level < depth; level++ {
+			indent := strings.Repeat("  ", level+1)
+			fmt.Fprintf(&text, "%ssetting_%d = value %d.%d\n", indent, level, section, level)
+			fmt.Fprintf(&text, "%slevel_%d =\n", indent, level)
+		}
+		fmt.Fprintf(&text, "%sleaf = deepest value %d\n", strings.Repeat("  ", depth+1), section)
+	}
+
+	path := []string{"section_0"}
+	for level := 0; level < depth; level++ {
+		path = append(path, fmt.Sprintf("level_%d", level))
+	}
+	return Document{
+		Name:  "deep_nesting",
+		Text:  text.String(),
+		Get:   "get_string",
+		Path:  append(path, "leaf"),
+		Shape: fmt.Sprintf("%d levels deep", depth),
+	}
+}
+
+// wideMap has thousands of keys at the top level
+func wideMap(scale int) Document {
+	keys := 5000 * scale
+	var text strings.Builder
+	for i := 0; i < keys; i++ {
+		fmt.Fprintf(&text, "service_%d_port = %d\n", i, 1024+i)
+		fmt.Fprintf(&text, "service_%d_name = service number %d\n", i, i)
+	}
+	return Document{
+		Name:  "wide_map",
+		Text:  text.String(),
+		Get:   "get_int",
+		Path:  []string{fmt.Sprintf("service_%d_port", keys/2)},
+		Shape: fmt.Sprintf("%d top-level keys", 2*keys),
+	}
+}
+
+// multilineValues has values continued over many indented lines
+func multilineValues(scale int) Document {
+	const lines = 40
+	var text strings.Builder
+	for i := 0; i < 100*scale; i++ {
+		fmt.Fprintf(&text, "description_%d = First line of description %d\n", i, i)
+		for line := 0; line < lines; line++ {
+			fmt.Fprintf(&text, "  continuation line %d of a long value that wraps over many lines\n", line)
+		}
+	}
+	return Document{
+		Name:  "multiline_values",
+		Text:  text.String(),
+		Get:   "get_string",
+		Path:  []string{"description_0"},
+		Shape: fmt.Sprintf("%d-line values", lines+1),
+	}
+}
+
+// manyComments interleaves comment lines with a few settings
+func manyComments(scale int) Document {
+	var text strings.Builder
+	for i := 0; i < 1000*scale; i++ {
+		fmt.Fprintf(&text, "/= Comment %d explains the setting below in some detail\n", i)
+		fmt.Fprintf(&text, "/= and continues on a second comment line\n")
+		fmt.Fprintf(&text, "enabled_%d = %t\n", i, i%2 == 0)
+	}
+	return Document{
+		Name:  "many_comments",
+		Text:  text.String(),
+		Get:   "get_bool",
+		Path:  []string{"enabled_0"},
+		Shape: "two comments per setting",
+	}
+}
+
+// largeLists repeats a key for a top-level list, and nests an empty-key list
+func largeLists(scale int) Document {
+	items := 4000 * scale
+	var text strings.Builder
+	for i := 0; i < items; i++ {
+		fmt.Fprintf(&text, "host = host-%d.example.com\n", i)
+	}
+	text.WriteString("servers =\n")
+	for i := 0; i < items; i++ {
+		fmt.Fprintf(&text, "  = server-%d.example.com\n", i)
+	}
+	return Document{
+		Name:  "large_lists",
+		Text:  text.String(),
+		Get:   "get_list",
+		Path:  []string{"host"},
+		Shape: fmt.Sprintf("lists of %d items", items),
+	}
+}
+
+// Operation is a CCL function benchmarked on every corpus document
+type Operation struct {
+	Name     string // Benchmark name prefix, e.g. parse
+	Function string // Function run on the implementation, e.g. build_hierarchy
+}
+
+// Operations are the implementation functions benchmarked. Typed access runs the
+// document's own get_* function.
+var Operations = []Operation{
+	{Name: "parse", Function: "parse"},
+	{Name: "build-hierarchy", Function: "build_hierarchy"},
+	{Name: "typed-access", Function: ""},
+	{Name: "round-trip", Function: "round_trip"},
+}
+
+// SkippedBenchmark is an operation that could not be benchmarked on a document
+type SkippedBenchmark struct {
+	Name   string
+	Reason string
+}
+
+// MeasureImplementation benchmarks every operation on every document against impl,
+// recording results named operation/document with the input size for throughput.
+// Operations the implementation does not support, or that report an error on a
+// document, are skipped rather than timed, since timing an error path would be
+// misleading. A failure to run the implementation at all is returned as an error.
+func (bt *BenchmarkTracker) MeasureImplementation(impl implementation.Implementation, corpus []Document, opts SampleOptions) ([]SkippedBenchmark, error) {
+	var skipped []SkippedBenchmark
+	for _, operation := range Operations {
+		for _, document := range corpus {
+			name := operation.Name + "/" + document.Name
+			function, args := operation.Function, []string(nil)
+			if function == "" {
+				function, args = document.Get, document.Path
+			}
+			inputs := []string{document.Text}
+
+			// Check the operation once before timing it
+			result, err := impl.Run(function, inputs, args)
+			if errors.Is(err, implementation.ErrUnsupported) {
+				skipped = append(skipped, SkippedBenchmark{Name: name, Reason: function + " is not supported"})
+				continue
+			}
+			if err != nil {
+				return skipped, fmt.Errorf("%s: %w", name, err)
+			}
+			if result.Error != "" {
+				skipped = append(skipped, SkippedBenchmark{Name: name, Reason: function + " reported an error: " + firstLine(result.Error)})
+				continue
+			}
+
+			measured, err := bt.Measure(name, opts, func() error {
+				_, err := impl.Run(function, inputs, args)
+				return err
+			})
+			if err != nil {
+				return skipped, fmt.Errorf("%s: %w", name, err)
+			}
+			measured.Bytes = int64(len(document.Text))
+		}
+	}
+	return skipped, nil
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
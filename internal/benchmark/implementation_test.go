@@ -0,0 +1,48 @@
+package benchmark
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+)
+
+func TestMeasureImplementation(t *testing.T) {
+	corpus := Corpus(1)
+	for _, document := range corpus {
+		if size := len(document.Text); size < 100_000 || size > 350_000 {
+			t.Errorf("%s is %d bytes, want 100-350 KB at scale 1", document.Name, size)
+		}
+	}
+
+	tracker := NewTracker()
+	skipped, err := tracker.MeasureImplementation(implementation.NewMock(), corpus, SampleOptions{Count: 1})
+	if err != nil {
+		t.Fatalf("MeasureImplementation() error = %v", err)
+	}
+
+	// The mock does not build nested objects, so the deep typed access is skipped
+	if len(skipped) != 1 || skipped[0].Name != "typed-access/deep_nesting" {
+		t.Errorf("skipped = %+v, want only typed-access/deep_nesting", skipped)
+	}
+	results := tracker.GetAllResults()
+	var names []string
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := []string{
+		"build-hierarchy/deep_nesting", "build-hierarchy/large_lists", "build-hierarchy/many_comments", "build-hierarchy/multiline_values", "build-hierarchy/wide_map",
+		"parse/deep_nesting", "parse/large_lists", "parse/many_comments", "parse/multiline_values", "parse/wide_map",
+		"round-trip/deep_nesting", "round-trip/large_lists", "round-trip/many_comments", "round-trip/multiline_values", "round-trip/wide_map",
+		"typed-access/large_lists", "typed-access/many_comments", "typed-access/multiline_values", "typed-access/wide_map",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("benchmarks run against the mock = %v, want %v", names, want)
+	}
+	result := results["parse/wide_map"]
+	if result == nil || result.Bytes != int64(len(corpus[1].Text)) || result.Throughput() <= 0 {
+		t.Errorf("parse/wide_map = %+v", result)
+	}
+}
@@ -0,0 +1,330 @@
+package benchmark
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sample is one measured run of a repeatedly sampled benchmark
+type Sample struct {
+	Duration      time.Duration `json:"duration"`
+	MemAllocBytes int64         `json:"memAllocBytes"`
+	MemAllocObjs  int64         `json:"memAllocObjs"`
+}
+
+// SampleOptions sets how often Measure runs an operation
+type SampleOptions struct {
+	Warmup int // Unmeasured runs first, to fill caches and settle the runtime
+	Count  int // Measured runs
+}
+
+// Measure runs fn Warmup times unmeasured, then Count times measured, and records a
+// result whose Duration and memory are the means of the samples. The samples are
+// kept for Summary, the significance test in CompareResults and WriteGoBenchmarks.
+func (bt *BenchmarkTracker) Measure(name string, opts SampleOptions, fn func() error) (*BenchmarkResult, error) {
+	if opts.Count < 1 {
+		opts.Count = 1
+	}
+	for i := 0; i < opts.Warmup; i++ {
+		if err := fn(); err != nil {
+			return nil, err
+		}
+	}
+
+	var samples []Sample
+	var last *BenchmarkResult
+	for i := 0; i < opts.Count; i++ {
+		bt.StartBenchmark(name)
+		err := fn()
+		last = bt.EndBenchmark(name)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, Sample{
+			Duration:      last.Duration,
+			MemAllocBytes: last.MemAllocBytes,
+			MemAllocObjs:  last.MemAllocObjs,
+		})
+	}
+
+	result := &BenchmarkResult{
+		Name:      name,
+		Timestamp: last.Timestamp,
+		GitCommit: last.GitCommit,
+		GoVersion: last.GoVersion,
+		Samples:   samples,
+	}
+	var duration, bytes, objects int64
+	for _, sample := range samples {
+		duration += int64(sample.Duration)
+		bytes += sample.MemAllocBytes
+		objects += sample.MemAllocObjs
+	}
+	count := int64(len(samples))
+	result.Duration = time.Duration(duration / count)
+	result.MemAllocBytes = bytes / count
+	result.MemAllocObjs = objects / count
+
+	bt.results[name] = result
+	return result, nil
+}
+
+// durations are the sampled durations in nanoseconds, or the single duration of an
+// unsampled result
+func (r *BenchmarkResult) durations() []float64 {
+	if len(r.Samples) == 0 {
+		return []float64{float64(r.Duration)}
+	}
+	values := make([]float64, len(r.Samples))
+	for i, sample := range r.Samples {
+		values[i] = float64(sample.Duration)
+	}
+	return values
+}
+
+// memory are the sampled allocated bytes, or the single value of an unsampled result
+func (r *BenchmarkResult) memory() []float64 {
+	if len(r.Samples) == 0 {
+		return []float64{float64(r.MemAllocBytes)}
+	}
+	values := make([]float64, len(r.Samples))
+	for i, sample := range r.Samples {
+		values[i] = float64(sample.MemAllocBytes)
+	}
+	return values
+}
+
+// Summary describes the spread of a result's duration samples
+type Summary struct {
+	N      int           `json:"n"`
+	Mean   time.Duration `json:"mean"`
+	StdDev time.Duration `json:"stddev"`
+	CILow  time.Duration `json:"ciLow"` // 95% confidence interval of the mean
+	CIHigh time.Duration `json:"ciHigh"`
+}
+
+// Summary computes the mean, sample standard deviation and 95% confidence interval
+// of the duration samples. Results with one sample have no spread.
+func (r *BenchmarkResult) Summary() Summary {
+	values := r.durations()
+	mean, stddev := meanStdDev(values)
+	margin := 0.0
+	if len(values) > 1 {
+		margin = tCritical(len(values)-1) * stddev / math.Sqrt(float64(len(values)))
+	}
+	return Summary{
+		N:      len(values),
+		Mean:   time.Duration(mean),
+		StdDev: time.Duration(stddev),
+		CILow:  time.Duration(mean - margin),
+		CIHigh: time.Duration(mean + margin),
+	}
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+// tTable holds the two-sided 95% critical values of Student's t distribution for
+// 1 to 30 degrees of freedom
+var tTable = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tCritical is the two-sided 95% critical value for df degrees of freedom, using the
+// normal approximation beyond the table
+func tCritical(df int) float64 {
+	if df >= 1 && df <= len(tTable) {
+		return tTable[df-1]
+	}
+	return 1.96
+}
+
+// maxExactSamples is the largest sample size for which MannWhitney computes the exact
+// distribution of U rather than its normal approximation
+const maxExactSamples = 25
+
+// MannWhitney returns the two-sided p-value of the Mann-Whitney U test that samples a
+// and b come from the same distribution. Unlike a t-test it assumes nothing about the
+// shape of the distribution, which suits the skewed timings of benchmarks. Small
+// samples without ties use the exact distribution; others the normal approximation
+// with tie and continuity correction.
+func MannWhitney(a, b []float64) float64 {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type observation struct {
+		value float64
+		first bool
+	}
+	all := make([]observation, 0, n1+n2)
+	for _, value := range a {
+		all = append(all, observation{value, true})
+	}
+	for _, value := range b {
+		all = append(all, observation{value, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// Rank the observations, giving tied values their average rank
+	var rankSum, tieTerm float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].first {
+				rankSum += rank
+			}
+		}
+		ties := float64(j - i)
+		tieTerm += ties*ties*ties - ties
+		i = j
+	}
+
+	u1 := rankSum - float64(n1*(n1+1))/2
+	u := math.Min(u1, float64(n1*n2)-u1)
+
+	if tieTerm == 0 && n1 <= maxExactSamples && n2 <= maxExactSamples {
+		return math.Min(1, 2*exactUCDF(n1, n2, int(u)))
+	}
+
+	n := float64(n1 + n2)
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (float64(n1*n2)/2 - u - 0.5) / math.Sqrt(variance)
+	if z <= 0 {
+		return 1
+	}
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// MinPValue is the smallest p-value MannWhitney returns for samples of n1 and n2
+// distinct values, 2/C(n1+n2, n1), reached when every value of one sample is below
+// every value of the other. A significance level at or below it is never reached.
+func MinPValue(n1, n2 int) float64 {
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	orderings := 1.0
+	for i := 1; i <= n1; i++ {
+		orderings = orderings * float64(n2+i) / float64(i)
+	}
+	return math.Min(1, 2/orderings)
+}
+
+// exactUCDF is the probability that U is at most u for samples of n1 and n2 values
+// drawn from the same distribution
+func exactUCDF(n1, n2, u int) float64 {
+	// counts[j][k] is the number of orderings of i values of a and j of b with U = k,
+	// built up one value of a at a time
+	counts := make([][]float64, n2+1)
+	for j := range counts {
+		counts[j] = []float64{1}
+	}
+	for i := 1; i <= n1; i++ {
+		next := make([][]float64, n2+1)
+		next[0] = []float64{1}
+		for j := 1; j <= n2; j++ {
+			// The largest value is either from a, exceeding all j values of b, or from b
+			row := make([]float64, i*j+1)
+			for k, count := range counts[j] {
+				row[k+j] += count
+			}
+			for k, count := range next[j-1] {
+				row[k] += count
+			}
+			next[j] = row
+		}
+		counts = next
+	}
+
+	var below, total float64
+	for k, count := range counts[n2] {
+		total += count
+		if k <= u {
+			below += count
+		}
+	}
+	return below / total
+}
+
+// WriteGoBenchmarks writes results in the Go benchmark text format, one line per
+// sample, so that benchstat and other tools for 'go test -bench' output can read them.
+// Operation names are converted to benchmark names, e.g. test-generation becomes
+// BenchmarkTestGeneration and parse/wide_map becomes the sub-benchmark
+// BenchmarkParse/wide_map. Results with an input size also report MB/s.
+func WriteGoBenchmarks(w io.Writer, results map[string]*BenchmarkResult) error {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "goos: %s\ngoarch: %s\npkg: github.com/catconflang/ccl-test-data\n", runtime.GOOS, runtime.GOARCH)
+	for _, name := range names {
+		if commit := results[name].GitCommit; commit != "" {
+			fmt.Fprintf(&out, "commit: %s\n", commit)
+			break
+		}
+	}
+
+	for _, name := range names {
+		result := results[name]
+		samples := result.Samples
+		if len(samples) == 0 {
+			samples = []Sample{{Duration: result.Duration, MemAllocBytes: result.MemAllocBytes, MemAllocObjs: result.MemAllocObjs}}
+		}
+		for _, sample := range samples {
+			fmt.Fprintf(&out, "%s\t%8d\t%12d ns/op", goBenchmarkName(name), 1, int64(sample.Duration))
+			if result.Bytes > 0 && sample.Duration > 0 {
+				fmt.Fprintf(&out, "\t%8.2f MB/s", float64(result.Bytes)/1e6/sample.Duration.Seconds())
+			}
+			fmt.Fprintf(&out, "\t%12d B/op\t%8d allocs/op\n", sample.MemAllocBytes, sample.MemAllocObjs)
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func goBenchmarkName(name string) string {
+	operation, sub, hasSub := strings.Cut(name, "/")
+	var benchmark strings.Builder
+	benchmark.WriteString("Benchmark")
+	for _, part := range strings.FieldsFunc(operation, func(r rune) bool {
+		return r == '-' || r == '_' || r == ' '
+	}) {
+		benchmark.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if hasSub {
+		benchmark.WriteString("/" + strings.ReplaceAll(sub, " ", "_"))
+	}
+	return benchmark.String()
+}
@@ -0,0 +1,117 @@
+package benchmark
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMannWhitney(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{"interleaved", []float64{1, 3, 5, 7, 9}, []float64{2, 4, 6, 8, 10}, 0.690476},
+		{"identical", []float64{5, 5, 5}, []float64{5, 5, 5}, 1},
+		// Ties use the normal approximation: U = 0.5, sigma = 4.743, z = 2.424
+		{"ties", []float64{1, 2, 2, 3, 4}, []float64{4, 5, 5, 6, 7}, 0.015333},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MannWhitney(tt.a, tt.b); math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("MannWhitney() = %.6f, want %.6f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinPValue(t *testing.T) {
+	// Fully separated samples of distinct values reach the smallest p-value
+	for _, n := range []int{2, 3, 4, 5} {
+		a, b := make([]float64, n), make([]float64, n)
+		for i := range a {
+			a[i], b[i] = float64(i), float64(n+i)
+		}
+		if got, want := MinPValue(n, n), MannWhitney(a, b); math.Abs(got-want) > 1e-12 {
+			t.Errorf("MinPValue(%d, %d) = %.6f, MannWhitney gives %.6f", n, n, got, want)
+		}
+	}
+	if got := MinPValue(3, 3); got != 0.1 {
+		t.Errorf("MinPValue(3, 3) = %v, want 0.1", got)
+	}
+
+	sampled := func(n int) *BenchmarkResult { return &BenchmarkResult{Samples: make([]Sample, n)} }
+	current := map[string]*BenchmarkResult{"small": sampled(3), "large": sampled(5), "one-run": {}}
+	historical := map[string]*BenchmarkResult{"small": sampled(3), "large": sampled(5), "one-run": {}}
+	if got := ComparablePValue(current, historical); got != 0.1 {
+		t.Errorf("ComparablePValue() = %v, want 0.1 from the 3-sample benchmark", got)
+	}
+	delete(current, "small")
+	if got := ComparablePValue(current, historical); got >= 0.05 {
+		t.Errorf("ComparablePValue() = %v, want below 0.05 with 5 samples per side", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	result := &BenchmarkResult{Samples: []Sample{{Duration: 10}, {Duration: 12}, {Duration: 14}}}
+	summary := result.Summary()
+	// stddev 2, margin 4.303 * 2 / sqrt(3)
+	if summary.N != 3 || summary.Mean != 12 || summary.StdDev != 2 || summary.CILow != 7 || summary.CIHigh != 16 {
+		t.Errorf("Summary() = %+v", summary)
+	}
+}
+
+func TestCompareResults(t *testing.T) {
+	sampled := func(durations ...time.Duration) *BenchmarkResult {
+		result := &BenchmarkResult{}
+		for _, duration := range durations {
+			result.Samples = append(result.Samples, Sample{Duration: duration, MemAllocBytes: 100})
+		}
+		result.Duration, result.MemAllocBytes = durations[0], 100
+		return result
+	}
+	historical := map[string]*BenchmarkResult{
+		"noisy":   sampled(100, 140, 90, 105, 95),
+		"slower":  sampled(100, 102, 98, 101, 99),
+		"one-run": {Duration: 100, MemAllocBytes: 100},
+	}
+	current := map[string]*BenchmarkResult{
+		"noisy":   sampled(150, 95, 100, 92, 110),
+		"slower":  sampled(130, 128, 131, 129, 132),
+		"one-run": {Duration: 120, MemAllocBytes: 100},
+	}
+
+	alerts := CompareResults(current, historical, 10, 0.05)
+	var names []string
+	for _, alert := range alerts {
+		names = append(names, alert.BenchmarkName)
+	}
+	if strings.Join(names, ",") != "one-run,slower" {
+		t.Fatalf("CompareResults() alerted %v, want [one-run slower]", names)
+	}
+	if alerts[0].PValue != 0 || alerts[1].PValue >= 0.05 {
+		t.Errorf("p-values = %v, %v", alerts[0].PValue, alerts[1].PValue)
+	}
+}
+
+func TestWriteGoBenchmarks(t *testing.T) {
+	var out strings.Builder
+	err := WriteGoBenchmarks(&out, map[string]*BenchmarkResult{
+		"test-generation": {Samples: []Sample{{Duration: 1500, MemAllocBytes: 64, MemAllocObjs: 2}, {Duration: 1600}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"pkg: github.com/catconflang/ccl-test-data\n",
+		"BenchmarkTestGeneration\t       1\t        1500 ns/op\t          64 B/op\t       2 allocs/op\n",
+		"BenchmarkTestGeneration\t       1\t        1600 ns/op",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
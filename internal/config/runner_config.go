@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/loader"
 )
 
 // RunnerConfig centralizes all behavioral choices, feature selections, and implementation capabilities
@@ -24,6 +25,8 @@ type ImplementationSettings struct {
 	Version            string               `json:"version"`
 	SupportedFunctions []config.CCLFunction `json:"supported_functions"`
 	SupportedFeatures  []config.CCLFeature  `json:"supported_features"`
+	IncludedTiers      []config.CCLTier     `json:"included_tiers,omitempty"` // Test tiers to run in addition to core
+	CorpusVersion      string               `json:"corpus_version,omitempty"` // Pinned corpus version; later tests are skipped
 }
 
 // BehaviorChoices contains REQUIRED mutually exclusive behavioral choices
@@ -192,6 +195,13 @@ func (rc *RunnerConfig) Validate() error {
 		}
 	}
 
+	// Validate the pinned corpus version
+	if rc.Implementation.CorpusVersion != "" {
+		if _, err := loader.ParseVersion(rc.Implementation.CorpusVersion); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(errors, "\n  - "))
 	}
@@ -256,11 +266,18 @@ func (rc *RunnerConfig) ToImplementationConfig() config.ImplementationConfig {
 		Version:            rc.Implementation.Version,
 		SupportedFunctions: rc.Implementation.SupportedFunctions,
 		SupportedFeatures:  rc.Implementation.SupportedFeatures,
+		IncludedTiers:      rc.Implementation.IncludedTiers,
+		CorpusVersion:      rc.Implementation.CorpusVersion,
 		BehaviorChoices:    behaviorChoices,
 		VariantChoice:      variantChoice,
 	}
 }
 
+// IncludesTier reports whether tests from a tier should run. Core tests always run.
+func (rc *RunnerConfig) IncludesTier(tier string) bool {
+	return rc.ToImplementationConfig().HasTier(config.CCLTier(tier))
+}
+
 // GetConflictingTags returns tags that should be filtered out based on behavioral choices
 func (rc *RunnerConfig) GetConflictingTags() []string {
 	var conflictingTags []string
@@ -8,17 +8,20 @@ import (
 	"strings"
 
 	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/loader"
 	"gopkg.in/yaml.v3"
 )
 
 // SimpleConfig represents the simplified YAML configuration format.
-// All fields are arrays of strings for maximum simplicity.
+// All fields apart from the pinned corpus version are arrays of strings for maximum simplicity.
 type SimpleConfig struct {
-	Functions []string `yaml:"functions" json:"functions"`
-	Features  []string `yaml:"features,omitempty" json:"features,omitempty"`
-	Behaviors []string `yaml:"behaviors,omitempty" json:"behaviors,omitempty"`
-	Variants  []string `yaml:"variants,omitempty" json:"variants,omitempty"`
-	SkipTests []string `yaml:"skip_tests,omitempty" json:"skip_tests,omitempty"`
+	Functions     []string `yaml:"functions" json:"functions"`
+	Features      []string `yaml:"features,omitempty" json:"features,omitempty"`
+	Behaviors     []string `yaml:"behaviors,omitempty" json:"behaviors,omitempty"`
+	Variants      []string `yaml:"variants,omitempty" json:"variants,omitempty"`
+	Tiers         []string `yaml:"tiers,omitempty" json:"tiers,omitempty"`
+	CorpusVersion string   `yaml:"corpus_version,omitempty" json:"corpus_version,omitempty"`
+	SkipTests     []string `yaml:"skip_tests,omitempty" json:"skip_tests,omitempty"`
 }
 
 // ValidFunctions defines all supported CCL functions
@@ -68,6 +71,12 @@ var ValidVariants = []string{
 	"reference_compliant",
 }
 
+// ValidTiers defines the test tiers that can be opted into. Core tests always run.
+var ValidTiers = []string{
+	"core",
+	"experimental",
+}
+
 // ConflictingBehaviors defines mutually exclusive behavioral choices
 var ConflictingBehaviors = [][]string{
 	{"boolean_strict", "boolean_lenient"},
@@ -149,6 +158,20 @@ func (c *SimpleConfig) Validate() error {
 		}
 	}
 
+	// Validate tiers
+	for _, tier := range c.Tiers {
+		if !slices.Contains(ValidTiers, tier) {
+			errors = append(errors, fmt.Sprintf("invalid tier: %s (valid: %s)", tier, strings.Join(ValidTiers, ", ")))
+		}
+	}
+
+	// Validate the pinned corpus version
+	if c.CorpusVersion != "" {
+		if _, err := loader.ParseVersion(c.CorpusVersion); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("validation errors:\n  - %s", strings.Join(errors, "\n  - "))
 	}
@@ -277,9 +300,7 @@ func (c *SimpleConfig) ToRunnerConfig() (*RunnerConfig, error) {
 			proposed := config.VariantProposed
 			variant.Specification = &proposed
 		case "reference_compliant":
-			// Note: Using the same constant as proposed for now
-			// TODO: Update when VariantReferenceCompliant is available
-			reference := config.VariantProposed
+			reference := config.VariantReference
 			variant.Specification = &reference
 		}
 	} else {
@@ -288,12 +309,19 @@ func (c *SimpleConfig) ToRunnerConfig() (*RunnerConfig, error) {
 		variant.Specification = &proposed
 	}
 
+	var includedTiers []config.CCLTier
+	for _, tier := range c.Tiers {
+		includedTiers = append(includedTiers, config.CCLTier(tier))
+	}
+
 	return &RunnerConfig{
 		Implementation: ImplementationSettings{
 			Name:               "ccl-test-runner",
 			Version:            "1.0.0",
 			SupportedFunctions: supportedFunctions,
 			SupportedFeatures:  supportedFeatures,
+			IncludedTiers:      includedTiers,
+			CorpusVersion:      c.CorpusVersion,
 		},
 		Behaviors: behaviors,
 		Variant:   variant,
@@ -0,0 +1,307 @@
+// Package corpusdiff compares two copies of the test corpus, such as two git revisions
+// of this repository, and reports the flat tests added, removed or changed between them.
+//
+// Tests are matched by name and validation. A matched test whose inputs, expected
+// value, arguments or error expectation differ has changed in expectation. One whose
+// functions, features, behaviors, variants, conflicts, tier or versions differ has
+// changed in metadata. A test can change in both.
+package corpusdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Corpus is the flat tests of one copy of the corpus
+type Corpus struct {
+	Version string // Newest version declared by the flat files, if any
+	Tests   []types.TestCase
+}
+
+// Changelog lists the differences between two corpora
+type Changelog struct {
+	OldVersion         string   `json:"old_version,omitempty"`
+	NewVersion         string   `json:"new_version,omitempty"`
+	Added              []Entry  `json:"added"`
+	Removed            []Entry  `json:"removed"`
+	ExpectationChanged []Change `json:"expectation_changed"`
+	MetadataChanged    []Change `json:"metadata_changed"`
+}
+
+// Entry identifies a flat test
+type Entry struct {
+	Name       string `json:"name"`
+	Validation string `json:"validation"`
+	SourceTest string `json:"source_test,omitempty"`
+}
+
+// Change describes the fields that differ for a test present in both corpora
+type Change struct {
+	Entry
+	Fields []string               `json:"fields"` // Flat format names of the changed fields
+	Old    map[string]interface{} `json:"old"`
+	New    map[string]interface{} `json:"new"`
+}
+
+// field is a flat test field compared between corpora
+type field struct {
+	name  string
+	value func(types.TestCase) interface{}
+}
+
+var expectationFields = []field{
+	{"inputs", func(t types.TestCase) interface{} { return t.Inputs }},
+	{"expected", func(t types.TestCase) interface{} { return t.Expected }},
+	{"args", func(t types.TestCase) interface{} { return t.Args }},
+	{"expect_error", func(t types.TestCase) interface{} { return t.ExpectError }},
+}
+
+var metadataFields = []field{
+	{"functions", func(t types.TestCase) interface{} { return t.Functions }},
+	{"features", func(t types.TestCase) interface{} { return t.Features }},
+	{"behaviors", func(t types.TestCase) interface{} { return t.Behaviors }},
+	{"variants", func(t types.TestCase) interface{} { return t.Variants }},
+	{"conflicts", func(t types.TestCase) interface{} { return t.Conflicts }},
+	{"tier", func(t types.TestCase) interface{} { return t.Tier }},
+	{"added_in", func(t types.TestCase) interface{} { return t.AddedIn }},
+	{"changed_in", func(t types.TestCase) interface{} { return t.ChangedIn }},
+}
+
+// Load reads the generated_tests directory of a corpus checkout
+func Load(dir string) (*Corpus, error) {
+	files, err := loader.FindTestFiles(os.DirFS(dir), "generated_tests", loader.DiscoveryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no flat tests found in %s/generated_tests", dir)
+	}
+
+	testLoader := loader.NewTestLoader(dir, config.ImplementationConfig{})
+	corpus := &Corpus{}
+	var newest *loader.Version
+	for _, file := range files {
+		suite, err := testLoader.LoadTestFile(filepath.Join(dir, filepath.FromSlash(file)), loader.LoadOptions{
+			Format:     loader.FormatFlat,
+			FilterMode: loader.FilterAll,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		corpus.Tests = append(corpus.Tests, suite.Tests...)
+
+		if suite.Version == "" {
+			continue
+		}
+		version, err := loader.ParseVersion(suite.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if newest == nil || version.Compare(*newest) > 0 {
+			newest = &version
+		}
+	}
+	if newest != nil {
+		corpus.Version = newest.String()
+	}
+
+	return corpus, nil
+}
+
+// Diff compares an earlier corpus with a later one by test name and validation
+func Diff(before, after *Corpus) *Changelog {
+	changelog := &Changelog{
+		OldVersion:         before.Version,
+		NewVersion:         after.Version,
+		Added:              []Entry{},
+		Removed:            []Entry{},
+		ExpectationChanged: []Change{},
+		MetadataChanged:    []Change{},
+	}
+
+	oldTests := index(before.Tests)
+	newTests := index(after.Tests)
+
+	for key, test := range newTests {
+		oldTest, ok := oldTests[key]
+		if !ok {
+			changelog.Added = append(changelog.Added, entryFor(test))
+			continue
+		}
+		if change := compare(oldTest, test, expectationFields); change != nil {
+			changelog.ExpectationChanged = append(changelog.ExpectationChanged, *change)
+		}
+		if change := compare(oldTest, test, metadataFields); change != nil {
+			changelog.MetadataChanged = append(changelog.MetadataChanged, *change)
+		}
+	}
+	for key, test := range oldTests {
+		if _, ok := newTests[key]; !ok {
+			changelog.Removed = append(changelog.Removed, entryFor(test))
+		}
+	}
+
+	sortEntries(changelog.Added)
+	sortEntries(changelog.Removed)
+	sortChanges(changelog.ExpectationChanged)
+	sortChanges(changelog.MetadataChanged)
+	return changelog
+}
+
+// Empty reports whether the corpora have the same tests
+func (c *Changelog) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 &&
+		len(c.ExpectationChanged) == 0 && len(c.MetadataChanged) == 0
+}
+
+// Markdown renders the changelog as a Markdown document
+func (c *Changelog) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Test Corpus Changes\n\n")
+	if c.OldVersion != "" || c.NewVersion != "" {
+		fmt.Fprintf(&b, "Corpus version %s → %s\n\n", orUnknown(c.OldVersion), orUnknown(c.NewVersion))
+	}
+	if c.Empty() {
+		b.WriteString("No tests were added, removed or changed.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "| Added | Removed | Expectation changed | Metadata changed |\n")
+	fmt.Fprintf(&b, "|-------|---------|---------------------|------------------|\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d |\n", len(c.Added), len(c.Removed), len(c.ExpectationChanged), len(c.MetadataChanged))
+
+	writeEntries(&b, "Added", c.Added)
+	writeEntries(&b, "Removed", c.Removed)
+	writeChanges(&b, "Expectation Changed", c.ExpectationChanged)
+	writeChanges(&b, "Metadata Changed", c.MetadataChanged)
+	return b.String()
+}
+
+// key matches a test between corpora
+type key struct {
+	name, validation string
+}
+
+func index(tests []types.TestCase) map[key]types.TestCase {
+	byKey := make(map[key]types.TestCase, len(tests))
+	for _, test := range tests {
+		byKey[key{test.Name, test.Validation}] = test
+	}
+	return byKey
+}
+
+func entryFor(test types.TestCase) Entry {
+	return Entry{Name: test.Name, Validation: test.Validation, SourceTest: test.SourceTest}
+}
+
+// compare returns the fields that differ between the two versions of a test, or nil
+func compare(before, after types.TestCase, fields []field) *Change {
+	var change *Change
+	for _, f := range fields {
+		oldValue, newValue := f.value(before), f.value(after)
+		if equal(oldValue, newValue) {
+			continue
+		}
+		if change == nil {
+			change = &Change{
+				Entry: entryFor(after),
+				Old:   make(map[string]interface{}),
+				New:   make(map[string]interface{}),
+			}
+		}
+		change.Fields = append(change.Fields, f.name)
+		change.Old[f.name] = oldValue
+		change.New[f.name] = newValue
+	}
+	return change
+}
+
+// equal compares field values by their JSON encoding, treating missing and empty
+// lists alike
+func equal(a, b interface{}) bool {
+	return encode(a) == encode(b)
+}
+
+func encode(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%#v", value)
+	}
+	if s := string(data); s != "[]" {
+		return s
+	}
+	return "null"
+}
+
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Validation < entries[j].Validation
+	})
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Validation < changes[j].Validation
+	})
+}
+
+func writeEntries(b *strings.Builder, title string, entries []Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s (%d)\n\n", title, len(entries))
+	for _, entry := range entries {
+		fmt.Fprintf(b, "- %s (%s)\n", code(entry.Name), entry.Validation)
+	}
+}
+
+func writeChanges(b *strings.Builder, title string, changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s (%d)\n", title, len(changes))
+	for _, change := range changes {
+		fmt.Fprintf(b, "\n### %s (%s)\n\n", code(change.Name), change.Validation)
+		for _, name := range change.Fields {
+			fmt.Fprintf(b, "- %s: %s → %s\n", name, code(compact(change.Old[name])), code(compact(change.New[name])))
+		}
+	}
+}
+
+// compact encodes a value as single-line JSON
+func compact(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// code formats text as inline Markdown code
+func code(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+func orUnknown(version string) string {
+	if version == "" {
+		return "unknown"
+	}
+	return version
+}
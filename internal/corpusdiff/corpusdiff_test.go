@@ -0,0 +1,63 @@
+package corpusdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestDiff(t *testing.T) {
+	before := &Corpus{Version: "0.3.1", Tests: []types.TestCase{
+		{Name: "basic_parse", Validation: "parse", Inputs: []string{"a = 1"}, Expected: []interface{}{"a"}, Features: []string{}},
+		{Name: "removed_parse", Validation: "parse", Inputs: []string{"b = 2"}},
+		{Name: "tagged_parse", Validation: "parse", Inputs: []string{"c = 3"}, Features: []string{"comments"}},
+	}}
+	after := &Corpus{Version: "0.4.0", Tests: []types.TestCase{
+		{Name: "basic_parse", Validation: "parse", Inputs: []string{"a = 1"}, Expected: []interface{}{"a", "b"}},
+		{Name: "tagged_parse", Validation: "parse", Inputs: []string{"c = 3"}, Features: []string{"comments", "unicode"}},
+		{Name: "added_parse", Validation: "parse", Inputs: []string{"d = 4"}, AddedIn: "0.4.0"},
+	}}
+
+	changelog := Diff(before, after)
+
+	if got := names(changelog.Added); !reflect.DeepEqual(got, []string{"added_parse"}) {
+		t.Errorf("Added = %v", got)
+	}
+	if got := names(changelog.Removed); !reflect.DeepEqual(got, []string{"removed_parse"}) {
+		t.Errorf("Removed = %v", got)
+	}
+	if len(changelog.ExpectationChanged) != 1 || changelog.ExpectationChanged[0].Name != "basic_parse" ||
+		!reflect.DeepEqual(changelog.ExpectationChanged[0].Fields, []string{"expected"}) {
+		t.Errorf("ExpectationChanged = %+v", changelog.ExpectationChanged)
+	}
+	// Missing and empty feature lists are the same
+	if len(changelog.MetadataChanged) != 1 || changelog.MetadataChanged[0].Name != "tagged_parse" ||
+		!reflect.DeepEqual(changelog.MetadataChanged[0].Fields, []string{"features"}) {
+		t.Errorf("MetadataChanged = %+v", changelog.MetadataChanged)
+	}
+
+	markdown := changelog.Markdown()
+	for _, want := range []string{
+		"Corpus version 0.3.1 → 0.4.0",
+		"## Added (1)\n\n- `added_parse` (parse)",
+		"### `basic_parse` (parse)\n\n- expected: `[\"a\"]` → `[\"a\",\"b\"]`",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, markdown)
+		}
+	}
+
+	if !Diff(before, before).Empty() {
+		t.Error("Diff of a corpus with itself is not empty")
+	}
+}
+
+func names(entries []Entry) []string {
+	var result []string
+	for _, entry := range entries {
+		result = append(result, entry.Name)
+	}
+	return result
+}
@@ -0,0 +1,373 @@
+// Package docgen generates random but valid CCL documents for benchmarks and fuzzing.
+//
+// Documents are built from a seed and a Shape, so the same seed and shape always give
+// the same document. Because the generator builds the structure before rendering it,
+// the expected parse entries and object hierarchy are known by construction and are
+// returned with the text as an oracle. No bundled implementation reproduces the
+// hierarchy, since the mock builds only flat objects; the rules that derive it are
+// checked against the build_hierarchy expectations of the corpus instead.
+//
+// The oracle assumes the behaviors listed in Document.Behaviors. Generated documents
+// avoid constructs whose meaning is implementation-defined: values never contain '='
+// or line breaks, keys are unique within each object and contain no dots, and empty-key
+// lists have at least two items.
+package docgen
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Distribution is how lengths are drawn between a Length's bounds
+type Distribution string
+
+const (
+	Uniform     Distribution = "uniform"     // Every length equally likely
+	Normal      Distribution = "normal"      // Centered between the bounds
+	Exponential Distribution = "exponential" // Mostly short, with a long tail
+)
+
+// Length is a distribution of key or value lengths in characters
+type Length struct {
+	Distribution Distribution `json:"distribution"`
+	Min          int          `json:"min"`
+	Max          int          `json:"max"`
+}
+
+// ParseLength reads a length written as DISTRIBUTION:MIN-MAX, e.g. normal:4-40.
+// The distribution can be uniform, normal or exponential (exp).
+func ParseLength(s string) (Length, error) {
+	distribution, bounds, found := strings.Cut(s, ":")
+	if !found {
+		return Length{}, fmt.Errorf("invalid length %q (expected DISTRIBUTION:MIN-MAX)", s)
+	}
+	length := Length{Distribution: Distribution(distribution)}
+	if distribution == "exp" {
+		length.Distribution = Exponential
+	}
+	switch length.Distribution {
+	case Uniform, Normal, Exponential:
+	default:
+		return Length{}, fmt.Errorf("invalid length distribution %q (expected uniform, normal or exponential)", distribution)
+	}
+
+	low, high, found := strings.Cut(bounds, "-")
+	var err error
+	if length.Min, err = strconv.Atoi(low); err != nil || !found {
+		return Length{}, fmt.Errorf("invalid length bounds %q (expected MIN-MAX)", bounds)
+	}
+	if length.Max, err = strconv.Atoi(high); err != nil {
+		return Length{}, fmt.Errorf("invalid length bounds %q (expected MIN-MAX)", bounds)
+	}
+	if length.Min < 1 || length.Max < length.Min {
+		return Length{}, fmt.Errorf("invalid length bounds %q (expected 1 <= MIN <= MAX)", bounds)
+	}
+	return length, nil
+}
+
+// String formats the length the way ParseLength reads it
+func (l Length) String() string {
+	return fmt.Sprintf("%s:%d-%d", l.Distribution, l.Min, l.Max)
+}
+
+// draw picks a length from the distribution
+func (l Length) draw(rng *rand.Rand) int {
+	span := float64(l.Max - l.Min)
+	var offset float64
+	switch l.Distribution {
+	case Normal:
+		offset = span/2 + rng.NormFloat64()*span/6
+	case Exponential:
+		offset = rng.ExpFloat64() * span / 4
+	default:
+		offset = rng.Float64() * (span + 1)
+	}
+	return l.Min + int(math.Max(0, math.Min(span, math.Floor(offset))))
+}
+
+// Shape describes the documents to generate. Ratios are probabilities between 0 and 1.
+type Shape struct {
+	Keys         int     `json:"keys"`         // Entries at the top level
+	Depth        int     `json:"depth"`        // Maximum nesting below the top level
+	FanOut       int     `json:"fanOut"`       // Maximum entries in a nested object
+	NestRatio    float64 `json:"nestRatio"`    // Chance that an entry above the maximum depth is an object
+	ListRatio    float64 `json:"listRatio"`    // Chance that an entry is an empty-key list
+	ListItems    int     `json:"listItems"`    // Maximum items in a list, at least 2
+	KeyLength    Length  `json:"keyLength"`    // Key length in characters
+	ValueLength  Length  `json:"valueLength"`  // Value length in characters
+	UnicodeRatio float64 `json:"unicodeRatio"` // Chance that a character is non-ASCII
+	CommentRatio float64 `json:"commentRatio"` // Chance of a comment line before an entry
+	CRLFRatio    float64 `json:"crlfRatio"`    // Chance that a line ends with CRLF
+	TabRatio     float64 `json:"tabRatio"`     // Chance that an entry has a tab before '='
+	Indent       int     `json:"indent"`       // Spaces per nesting level
+}
+
+// DefaultShape is a moderately nested configuration file of about 50 top-level entries
+func DefaultShape() Shape {
+	return Shape{
+		Keys:         50,
+		Depth:        3,
+		FanOut:       6,
+		NestRatio:    0.3,
+		ListRatio:    0.1,
+		ListItems:    5,
+		KeyLength:    Length{Distribution: Uniform, Min: 3, Max: 12},
+		ValueLength:  Length{Distribution: Exponential, Min: 1, Max: 40},
+		CommentRatio: 0.1,
+		Indent:       2,
+	}
+}
+
+// Validate reports the first shape parameter out of range
+func (s Shape) Validate() error {
+	switch {
+	case s.Keys < 1:
+		return fmt.Errorf("keys must be at least 1")
+	case s.Depth < 0:
+		return fmt.Errorf("depth must not be negative")
+	case s.FanOut < 1:
+		return fmt.Errorf("fan-out must be at least 1")
+	case s.ListItems < 2:
+		return fmt.Errorf("list items must be at least 2")
+	case s.Indent < 1:
+		return fmt.Errorf("indent must be at least 1")
+	case s.KeyLength.Min < 1 || s.KeyLength.Max < s.KeyLength.Min:
+		return fmt.Errorf("invalid key length %s", s.KeyLength)
+	case s.ValueLength.Min < 1 || s.ValueLength.Max < s.ValueLength.Min:
+		return fmt.Errorf("invalid value length %s", s.ValueLength)
+	}
+	for _, ratio := range []struct {
+		name  string
+		value float64
+	}{
+		{"nest ratio", s.NestRatio}, {"list ratio", s.ListRatio}, {"unicode ratio", s.UnicodeRatio},
+		{"comment ratio", s.CommentRatio}, {"CRLF ratio", s.CRLFRatio}, {"tab ratio", s.TabRatio},
+	} {
+		if ratio.value < 0 || ratio.value > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", ratio.name)
+		}
+	}
+	return nil
+}
+
+// Document is a generated CCL document with its expected results
+type Document struct {
+	Seed      uint64                 `json:"seed"`
+	Shape     Shape                  `json:"shape"`
+	Text      string                 `json:"-"`
+	Behaviors []string               `json:"behaviors"` // Behaviors the expected results assume
+	Entries   []types.Entry          `json:"entries"`   // Expected result of parse
+	Hierarchy map[string]interface{} `json:"hierarchy"` // Expected result of build_hierarchy
+}
+
+// node is an entry of the generated structure
+type node struct {
+	comment  bool
+	key      string
+	value    string   // Leaf value, or comment text
+	children []node   // Entries of a nested object
+	items    []string // Items of an empty-key list
+	tab      bool     // Written with a tab before '='
+}
+
+// generator holds the state of one document's generation
+type generator struct {
+	shape Shape
+	rng   *rand.Rand
+}
+
+// Generate builds a document from seed and shape
+func Generate(seed uint64, shape Shape) (*Document, error) {
+	if err := shape.Validate(); err != nil {
+		return nil, err
+	}
+	g := &generator{shape: shape, rng: rand.New(rand.NewPCG(seed, 0x9e3779b97f4a7c15))}
+	nodes := g.object(0, shape.Keys)
+
+	var text strings.Builder
+	var entries []types.Entry
+	for _, n := range nodes {
+		lines := g.render(n, 0)
+		for _, line := range lines {
+			text.WriteString(line)
+			if g.chance(shape.CRLFRatio) {
+				text.WriteString("\r\n")
+			} else {
+				text.WriteString("\n")
+			}
+		}
+		entries = append(entries, entry(n, lines))
+	}
+
+	document := &Document{
+		Seed:      seed,
+		Shape:     shape,
+		Text:      text.String(),
+		Behaviors: []string{"array_order_insertion"},
+		Entries:   entries,
+		Hierarchy: hierarchy(nodes),
+	}
+	if shape.CRLFRatio > 0 {
+		document.Behaviors = append(document.Behaviors, "crlf_normalize_to_lf")
+	}
+	if shape.TabRatio > 0 {
+		// Nested values keep their lines verbatim, including the tabs before '='
+		document.Behaviors = append(document.Behaviors, "tabs_as_content")
+	}
+	return document, nil
+}
+
+func (g *generator) chance(ratio float64) bool {
+	return ratio > 0 && g.rng.Float64() < ratio
+}
+
+// object generates count entries at level, with comments before some of them
+func (g *generator) object(level, count int) []node {
+	var nodes []node
+	used := make(map[string]bool)
+	for i := 0; i < count; i++ {
+		if g.chance(g.shape.CommentRatio) {
+			nodes = append(nodes, node{comment: true, value: g.text(g.shape.ValueLength)})
+		}
+
+		base := g.key()
+		key := base
+		for suffix := 2; used[key]; suffix++ {
+			key = fmt.Sprintf("%s_%d", base, suffix)
+		}
+		used[key] = true
+		n := node{key: key, tab: g.chance(g.shape.TabRatio)}
+
+		switch {
+		case level < g.shape.Depth && g.chance(g.shape.NestRatio):
+			n.children = g.object(level+1, 1+g.rng.IntN(g.shape.FanOut))
+		case g.chance(g.shape.ListRatio):
+			for items := 2 + g.rng.IntN(g.shape.ListItems-1); len(n.items) < items; {
+				n.items = append(n.items, g.text(g.shape.ValueLength))
+			}
+		default:
+			n.value = g.text(g.shape.ValueLength)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Characters keys and values are drawn from
+var (
+	keyStart     = []rune("abcdefghijklmnopqrstuvwxyz")
+	keyRunes     = []rune("abcdefghijklmnopqrstuvwxyz0123456789_-")
+	valueRunes   = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789    .,:;/_-@#+")
+	unicodeRunes = []rune("éüßøåçñλΩπжЖщ日本語中文한국어αβγ€→✓🚀")
+)
+
+// key generates a key that starts with a letter and contains no spaces, dots or '='
+func (g *generator) key() string {
+	length := g.shape.KeyLength.draw(g.rng)
+	key := make([]rune, length)
+	for i := range key {
+		switch {
+		case g.chance(g.shape.UnicodeRatio):
+			key[i] = unicodeRunes[g.rng.IntN(len(unicodeRunes))]
+		case i == 0:
+			key[i] = keyStart[g.rng.IntN(len(keyStart))]
+		default:
+			key[i] = keyRunes[g.rng.IntN(len(keyRunes))]
+		}
+	}
+	return string(key)
+}
+
+// text generates a value or comment with no leading or trailing spaces, no '=' and
+// no line breaks
+func (g *generator) text(length Length) string {
+	value := make([]rune, length.draw(g.rng))
+	for i := range value {
+		if g.chance(g.shape.UnicodeRatio) {
+			value[i] = unicodeRunes[g.rng.IntN(len(unicodeRunes))]
+			continue
+		}
+		value[i] = valueRunes[g.rng.IntN(len(valueRunes))]
+		if value[i] == ' ' && (i == 0 || i == len(value)-1) {
+			value[i] = 'x'
+		}
+	}
+	return string(value)
+}
+
+// render writes a node as lines without line endings
+func (g *generator) render(n node, level int) []string {
+	indent := strings.Repeat(" ", level*g.shape.Indent)
+	if n.comment {
+		return []string{indent + "/= " + n.value}
+	}
+
+	separator := " = "
+	if n.tab {
+		separator = "\t= "
+	}
+	switch {
+	case n.children != nil:
+		lines := []string{indent + n.key + strings.TrimRight(separator, " ")}
+		for _, child := range n.children {
+			lines = append(lines, g.render(child, level+1)...)
+		}
+		return lines
+	case n.items != nil:
+		lines := []string{indent + n.key + strings.TrimRight(separator, " ")}
+		itemIndent := strings.Repeat(" ", (level+1)*g.shape.Indent)
+		for _, item := range n.items {
+			lines = append(lines, itemIndent+"= "+item)
+		}
+		return lines
+	}
+	return []string{indent + n.key + separator + n.value}
+}
+
+// entry is the expected parse result of a top-level node rendered as lines. Nested
+// values are the lines below the key, verbatim and after a leading newline.
+func entry(n node, lines []string) types.Entry {
+	if n.comment {
+		return types.Entry{Key: "/", Value: n.value}
+	}
+	if n.children != nil || n.items != nil {
+		return types.Entry{Key: n.key, Value: "\n" + strings.Join(lines[1:], "\n")}
+	}
+	return types.Entry{Key: n.key, Value: n.value}
+}
+
+// hierarchy is the expected object of a list of nodes. Keys are unique except for
+// comments, which become a list when an object has several.
+func hierarchy(nodes []node) map[string]interface{} {
+	object := make(map[string]interface{})
+	var comments []interface{}
+	for _, n := range nodes {
+		switch {
+		case n.comment:
+			comments = append(comments, n.value)
+		case n.children != nil:
+			object[n.key] = hierarchy(n.children)
+		case n.items != nil:
+			items := make([]interface{}, len(n.items))
+			for i, item := range n.items {
+				items[i] = item
+			}
+			object[n.key] = map[string]interface{}{"": items}
+		default:
+			object[n.key] = n.value
+		}
+	}
+	switch len(comments) {
+	case 0:
+	case 1:
+		object["/"] = comments[0]
+	default:
+		object["/"] = comments
+	}
+	return object
+}
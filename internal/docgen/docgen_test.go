@@ -0,0 +1,170 @@
+package docgen
+
+import (
+	"encoding/json"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+
+	ccl "github.com/catconflang/ccl-test-data"
+	"github.com/catconflang/ccl-test-data/internal/mock"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestGenerate(t *testing.T) {
+	shape := DefaultShape()
+	shape.Keys = 200
+	shape.Depth = 4
+	shape.UnicodeRatio = 0.1
+	shape.CRLFRatio = 0.2
+	shape.TabRatio = 0.2
+	shape.CommentRatio = 0.3
+	shape.ValueLength = Length{Distribution: Normal, Min: 1, Max: 30}
+
+	document, err := Generate(42, shape)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	again, _ := Generate(42, shape)
+	if again.Text != document.Text {
+		t.Error("Generate() is not deterministic for a seed")
+	}
+	if other, _ := Generate(43, shape); other.Text == document.Text {
+		t.Error("Generate() ignores the seed")
+	}
+	for _, want := range []string{"\r\n", "\t= ", "/= ", "  = "} {
+		if !strings.Contains(document.Text, want) {
+			t.Errorf("document has no %q", want)
+		}
+	}
+
+	// The mock parser agrees with the oracle's entries
+	parser := mock.New()
+	parsed, err := parser.Parse(document.Text)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := toEntries(parsed); !reflect.DeepEqual(got, document.Entries) {
+		t.Errorf("parsed entries differ from the oracle")
+		for i := range got {
+			if i < len(document.Entries) && got[i] != document.Entries[i] {
+				t.Fatalf("first difference at %d: got %q, want %q", i, got[i], document.Entries[i])
+			}
+		}
+	}
+
+}
+
+func TestParseLength(t *testing.T) {
+	length, err := ParseLength("exp:2-80")
+	if err != nil || length != (Length{Distribution: Exponential, Min: 2, Max: 80}) {
+		t.Errorf("ParseLength() = %+v, %v", length, err)
+	}
+	for _, invalid := range []string{"uniform", "zipf:1-2", "normal:5-1", "normal:0-3", "uniform:a-b"} {
+		if _, err := ParseLength(invalid); err == nil {
+			t.Errorf("ParseLength(%q) succeeded", invalid)
+		}
+	}
+}
+
+func toEntries(parsed []mock.Entry) []types.Entry {
+	entries := make([]types.Entry, len(parsed))
+	for i, entry := range parsed {
+		entries[i] = types.Entry{Key: entry.Key, Value: entry.Value}
+	}
+	return entries
+}
+
+// TestOracleMatchesCorpus renders structures the generator builds and checks the
+// text, entries and hierarchy derived from them against the corpus tests with the
+// same input. No bundled implementation builds nested objects, so the corpus is the
+// reference for the hierarchy oracle.
+func TestOracleMatchesCorpus(t *testing.T) {
+	leaf := func(key, value string) node { return node{key: key, value: value} }
+	tests := []struct {
+		file, name string
+		nodes      []node
+	}{
+		{"api_core_ccl_hierarchy.json", "basic_object_construction", []node{leaf("name", "Alice"), leaf("age", "42")}},
+		{"api_core_ccl_hierarchy.json", "deep_nested_objects", []node{{key: "server", children: []node{
+			{key: "database", children: []node{leaf("host", "localhost"), leaf("port", "5432")}},
+			{key: "cache", children: []node{leaf("enabled", "true")}},
+		}}}},
+		{"api_list_access.json", "bare_list_basic", []node{{key: "servers", items: []string{"web1", "web2", "web3"}}}},
+		{"api_list_access.json", "bare_list_nested", []node{{key: "network", children: []node{
+			{key: "ports", items: []string{"80", "443", "8080"}},
+		}}}},
+		{"api_list_access.json", "bare_list_mixed_with_other_keys", []node{{key: "database", children: []node{
+			leaf("host", "localhost"), leaf("port", "5432"), {key: "replicas", items: []string{"replica1", "replica2"}},
+		}}}},
+	}
+
+	g := &generator{shape: Shape{Indent: 2}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := corpusTest(t, tt.file, tt.name)
+
+			var lines []string
+			var entries []types.Entry
+			for _, n := range tt.nodes {
+				rendered := g.render(n, 0)
+				lines = append(lines, rendered...)
+				entries = append(entries, entry(n, rendered))
+			}
+			if text := strings.Join(lines, "\n"); text != source.Inputs[0] {
+				t.Fatalf("rendered %q, corpus input is %q", text, source.Inputs[0])
+			}
+
+			for _, validation := range source.Tests {
+				var got interface{}
+				switch validation.Function {
+				case "parse":
+					got = normalize(t, entries)
+				case "build_hierarchy":
+					got = hierarchy(tt.nodes)
+				default:
+					continue
+				}
+				if !reflect.DeepEqual(got, validation.Expect) {
+					t.Errorf("%s = %v, corpus expects %v", validation.Function, got, validation.Expect)
+				}
+			}
+		})
+	}
+}
+
+// corpusTest finds a source test in the embedded corpus
+func corpusTest(t *testing.T, file, name string) loader.CompactTest {
+	t.Helper()
+	data, err := fs.ReadFile(ccl.Corpus, "source_tests/core/"+file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var source loader.CompactTestFile
+	if err := json.Unmarshal(data, &source); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range source.Tests {
+		if test.Name == name {
+			return test
+		}
+	}
+	t.Fatalf("no test %s in %s", name, file)
+	return loader.CompactTest{}
+}
+
+// normalize converts a value to its decoded JSON form, as corpus expectations are
+func normalize(t *testing.T, value interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
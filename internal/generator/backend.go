@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Backend renders flat format test cases as test source code for one target language
+type Backend interface {
+	// Name returns the target name selected with generate --target
+	Name() string
+	// OutputPath returns the test file path for a flat JSON file, relative to the output directory
+	OutputPath(packageName, baseName string) string
+	// GenerateTest renders a single test case. A non-empty skipReason marks the test as skipped.
+	GenerateTest(test types.TestCase, skipReason string) (string, error)
+	// GenerateFile renders a complete test file from the rendered test cases
+	GenerateFile(data TemplateData) (string, error)
+}
+
+// backends lists the available backends in the order shown to users
+var backends = []Backend{
+	&goBackend{},
+	&pytestBackend{},
+	&jestBackend{},
+	&rustBackend{},
+}
+
+// Targets returns the names of all available backends
+func Targets() []string {
+	names := make([]string, len(backends))
+	for i, backend := range backends {
+		names[i] = backend.Name()
+	}
+	return names
+}
+
+// NewBackend returns the backend for a target name
+func NewBackend(target string) (Backend, error) {
+	for _, backend := range backends {
+		if backend.Name() == target {
+			return backend, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown target %q (available: %s)", target, strings.Join(Targets(), ", "))
+}
+
+// goBackend renders tests that run against the mock implementation with testify,
+// unless user-supplied templates override parts of the output
+type goBackend struct {
+	templates *Templates
+}
+
+// Name returns the target name
+func (b *goBackend) Name() string {
+	return "go"
+}
+
+// OutputPath places each test file in a package directory named after its feature
+func (b *goBackend) OutputPath(packageName, baseName string) string {
+	return filepath.Join(packageName, baseName+"_test.go")
+}
+
+// adapterCall describes how a non-Go backend exercises a flat test through the target
+// language's CCL adapter: the function to call, its arguments and the expected result.
+//
+// Adapter functions receive CCL text rather than parsed entries, and return plain data:
+// entry lists as [{"key": ..., "value": ...}], hierarchies as nested objects, and
+// scalars, lists, strings and booleans as themselves.
+type adapterCall struct {
+	Function     string      // CCL function name (snake_case)
+	Inputs       []string    // CCL input text(s)
+	MultiInput   bool        // Function receives all inputs as one list
+	Path         []string    // Key path for typed access functions
+	Expected     interface{} // Expected result in adapter data form
+	ExpectError  bool        // The call must fail
+	ErrorOrEmpty bool        // Either a failure or an empty result is accepted
+}
+
+// multiInputFunctions receive every test input as a single list argument
+var multiInputFunctions = map[string]bool{
+	"compose":             true,
+	"compose_associative": true,
+	"identity_left":       true,
+	"identity_right":      true,
+}
+
+// newAdapterCall converts a flat test case into the call made through a language adapter
+func newAdapterCall(test types.TestCase) (adapterCall, error) {
+	call := adapterCall{
+		Function:   test.Validation,
+		Inputs:     test.Inputs,
+		MultiInput: multiInputFunctions[test.Validation],
+	}
+	if call.Function == "combine" {
+		call.Function = "compose"
+		call.MultiInput = true
+	}
+	if !call.MultiInput && len(test.Inputs) != 1 {
+		return call, fmt.Errorf("%s validation requires exactly 1 input, got %d", test.Validation, len(test.Inputs))
+	}
+
+	typedAccess := strings.HasPrefix(call.Function, "get_")
+	if typedAccess {
+		call.Path = test.Args
+		if call.Path == nil {
+			call.Path = []string{}
+		}
+	}
+
+	expected, expectError, errorOrEmpty := implementation.FlatExpectation(test)
+	if expectError || errorOrEmpty {
+		call.ExpectError = expectError
+		call.ErrorOrEmpty = errorOrEmpty
+		return call, nil
+	}
+
+	// A round trip that expects text checks the printed form of the parsed input
+	if call.Function == "round_trip" {
+		if _, ok := expected.(string); ok {
+			call.Function = "print"
+		}
+	}
+
+	call.Expected = expected
+	return call, nil
+}
+
+// identifier converts a test name into an identifier valid in every target language
+func identifier(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	id := sb.String()
+	if id == "" || (id[0] >= '0' && id[0] <= '9') {
+		id = "_" + id
+	}
+	return id
+}
+
+// toCamelCase converts a snake_case function name to camelCase
+func toCamelCase(name string) string {
+	pascal := toPascalCase(name)
+	if pascal == "" {
+		return pascal
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
+// quoteJSON returns s as a JSON string literal, which is also valid in Python and TypeScript
+func quoteJSON(s string) string {
+	var sb strings.Builder
+	encoder := json.NewEncoder(&sb)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return strconv.Quote(s)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// formatNumber formats a JSON number without a fractional part when it is integral
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// literalSyntax describes how a target language writes JSON-compatible literals
+type literalSyntax struct {
+	null, yes, no string
+	quote         func(string) string
+	object        func(pairs []string) string
+	pair          func(key, value string) string
+	list          func(items []string) string
+}
+
+// format renders a JSON-compatible value as a literal, with object keys in sorted order
+func (ls literalSyntax) format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ls.null
+	case bool:
+		if v {
+			return ls.yes
+		}
+		return ls.no
+	case string:
+		return ls.quote(v)
+	case float64:
+		return formatNumber(v)
+	case int:
+		return strconv.Itoa(v)
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = ls.quote(item)
+		}
+		return ls.list(items)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = ls.format(item)
+		}
+		return ls.list(items)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, len(keys))
+		for i, key := range keys {
+			pairs[i] = ls.pair(ls.quote(key), ls.format(v[key]))
+		}
+		return ls.object(pairs)
+	default:
+		return ls.quote(fmt.Sprintf("%v", v))
+	}
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+const jestFileTemplate = `// Generated from {{.SourceFile}}
+// Suite: {{.Suite}}
+// Version: {{.Version}}
+{{if .Description}}// Description: {{.Description}}
+{{end}}
+import * as ccl from "./ccl_adapter";
+{{range .Tests}}
+{{.}}
+{{end}}`
+
+// typeScriptLiterals writes values as TypeScript literals
+var typeScriptLiterals = literalSyntax{
+	null:   "null",
+	yes:    "true",
+	no:     "false",
+	quote:  quoteJSON,
+	object: func(pairs []string) string { return "{" + strings.Join(pairs, ", ") + "}" },
+	pair:   func(key, value string) string { return key + ": " + value },
+	list:   func(items []string) string { return "[" + strings.Join(items, ", ") + "]" },
+}
+
+// jestBackend renders TypeScript tests for Jest, or Vitest with globals enabled,
+// that call a ccl_adapter module
+type jestBackend struct{}
+
+// Name returns the target name
+func (b *jestBackend) Name() string {
+	return "jest"
+}
+
+// OutputPath places every test file in the output directory next to ccl_adapter.ts
+func (b *jestBackend) OutputPath(packageName, baseName string) string {
+	return baseName + ".test.ts"
+}
+
+// GenerateFile renders a TypeScript test module from generated test blocks
+func (b *jestBackend) GenerateFile(data TemplateData) (string, error) {
+	tmpl, err := template.New("jest").Parse(jestFileTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse jest template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute jest template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// GenerateTest renders a single test block. Adapter functions use camelCase names.
+func (b *jestBackend) GenerateTest(test types.TestCase, skipReason string) (string, error) {
+	header := fmt.Sprintf("// %s - %s\n", test.Name, strings.Join(getTestTags(test), " "))
+	name := quoteJSON(test.Name)
+
+	if skipReason != "" {
+		return fmt.Sprintf("%stest.skip(%s, () => {\n  // %s\n});", header, name, skipReason), nil
+	}
+
+	call, err := newAdapterCall(test)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{}
+	if call.MultiInput {
+		args = append(args, typeScriptLiterals.format(call.Inputs))
+	} else {
+		args = append(args, quoteJSON(call.Inputs[0]))
+	}
+	if call.Path != nil {
+		args = append(args, typeScriptLiterals.format(call.Path))
+	}
+	invocation := fmt.Sprintf("ccl.%s(%s)", toCamelCase(call.Function), strings.Join(args, ", "))
+
+	var body string
+	switch {
+	case call.ExpectError:
+		body = fmt.Sprintf("  expect(() => %s).toThrow();", invocation)
+	case call.ErrorOrEmpty:
+		body = fmt.Sprintf(`  let result: unknown;
+  try {
+    result = %s;
+  } catch {
+    return;
+  }
+  expect(%s).toContainEqual(result);`, invocation, typeScriptLiterals.format(implementation.EmptyResults))
+	default:
+		body = fmt.Sprintf("  const result = %s;\n  expect(result).toEqual(%s);", invocation, typeScriptLiterals.format(call.Expected))
+	}
+
+	return fmt.Sprintf("%stest(%s, () => {\n%s\n});", header, name, body), nil
+}
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+const pytestFileTemplate = `"""Generated from {{.SourceFile}}
+
+Suite: {{.Suite}}
+Version: {{.Version}}
+{{if .Description}}Description: {{.Description}}
+{{end}}"""
+
+import pytest
+
+import ccl_adapter as ccl{{range .Tests}}
+
+
+{{.}}{{end}}
+`
+
+// pythonLiterals writes values as Python literals
+var pythonLiterals = literalSyntax{
+	null:   "None",
+	yes:    "True",
+	no:     "False",
+	quote:  quoteJSON,
+	object: func(pairs []string) string { return "{" + strings.Join(pairs, ", ") + "}" },
+	pair:   func(key, value string) string { return key + ": " + value },
+	list:   func(items []string) string { return "[" + strings.Join(items, ", ") + "]" },
+}
+
+// pytestBackend renders pytest tests that call a ccl_adapter module
+type pytestBackend struct{}
+
+// Name returns the target name
+func (b *pytestBackend) Name() string {
+	return "pytest"
+}
+
+// OutputPath places every test file in the output directory so pytest finds ccl_adapter.py next to it
+func (b *pytestBackend) OutputPath(packageName, baseName string) string {
+	return "test_" + baseName + ".py"
+}
+
+// GenerateFile renders a pytest module from generated test functions
+func (b *pytestBackend) GenerateFile(data TemplateData) (string, error) {
+	tmpl, err := template.New("pytest").Parse(pytestFileTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse pytest template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute pytest template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// GenerateTest renders a single pytest test function
+func (b *pytestBackend) GenerateTest(test types.TestCase, skipReason string) (string, error) {
+	header := fmt.Sprintf("# %s - %s\n", test.Name, strings.Join(getTestTags(test), " "))
+	name := "test_" + identifier(test.Name)
+
+	if skipReason != "" {
+		return fmt.Sprintf("%s@pytest.mark.skip(reason=%s)\ndef %s():\n    pass", header, quoteJSON(skipReason), name), nil
+	}
+
+	call, err := newAdapterCall(test)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{}
+	if call.MultiInput {
+		args = append(args, pythonLiterals.format(call.Inputs))
+	} else {
+		args = append(args, quoteJSON(call.Inputs[0]))
+	}
+	if call.Path != nil {
+		args = append(args, pythonLiterals.format(call.Path))
+	}
+	invocation := fmt.Sprintf("ccl.%s(%s)", call.Function, strings.Join(args, ", "))
+
+	var body string
+	switch {
+	case call.ExpectError:
+		body = fmt.Sprintf("    with pytest.raises(Exception):\n        %s", invocation)
+	case call.ErrorOrEmpty:
+		body = fmt.Sprintf(`    try:
+        result = %s
+    except Exception:
+        return
+    assert result in %s`, invocation, pythonLiterals.format(implementation.EmptyResults))
+	default:
+		body = fmt.Sprintf("    result = %s\n    assert result == %s", invocation, pythonLiterals.format(call.Expected))
+	}
+
+	return fmt.Sprintf("%sdef %s():\n%s", header, name, body), nil
+}
@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+const rustFileTemplate = `// Generated from {{.SourceFile}}
+// Suite: {{.Suite}}
+// Version: {{.Version}}
+{{if .Description}}// Description: {{.Description}}
+{{end}}
+mod ccl_adapter;
+
+#[allow(unused_imports)]
+use serde_json::Value;
+{{range .Tests}}
+{{.}}
+{{end}}`
+
+// rustBackend renders Rust #[test] functions that call a ccl_adapter module.
+// Adapter functions return Result<serde_json::Value, E> for any E: Debug.
+type rustBackend struct{}
+
+// Name returns the target name
+func (b *rustBackend) Name() string {
+	return "rust"
+}
+
+// OutputPath places every test file directly in the output directory, since Cargo only
+// discovers integration tests at the top level of tests/
+func (b *rustBackend) OutputPath(packageName, baseName string) string {
+	return baseName + ".rs"
+}
+
+// GenerateFile renders a Rust integration test file from generated test functions
+func (b *rustBackend) GenerateFile(data TemplateData) (string, error) {
+	tmpl, err := template.New("rust").Parse(rustFileTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse rust template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute rust template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// GenerateTest renders a single Rust test function
+func (b *rustBackend) GenerateTest(test types.TestCase, skipReason string) (string, error) {
+	header := fmt.Sprintf("// %s - %s\n#[test]\n", test.Name, strings.Join(getTestTags(test), " "))
+	name := identifier(test.Name)
+
+	if skipReason != "" {
+		return fmt.Sprintf("%s#[ignore = %s]\nfn %s() {}", header, rustString(skipReason), name), nil
+	}
+
+	call, err := newAdapterCall(test)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{}
+	if call.MultiInput {
+		args = append(args, rustSlice(call.Inputs))
+	} else {
+		args = append(args, rustString(call.Inputs[0]))
+	}
+	if call.Path != nil {
+		args = append(args, rustSlice(call.Path))
+	}
+	invocation := fmt.Sprintf("ccl_adapter::%s(%s)", call.Function, strings.Join(args, ", "))
+
+	var body string
+	switch {
+	case call.ExpectError:
+		body = fmt.Sprintf("    assert!(%s.is_err());", invocation)
+	case call.ErrorOrEmpty:
+		body = fmt.Sprintf(`    if let Ok(result) = %s {
+        let empty: Value = serde_json::from_str(%s).unwrap();
+        assert!(empty.as_array().unwrap().contains(&result), "expected an empty result, got {}", result);
+    }`, invocation, rustRawString(jsonText(implementation.EmptyResults)))
+	default:
+		body = fmt.Sprintf(`    let result = %s.expect("%s failed");
+    let expected: Value = serde_json::from_str(%s).unwrap();
+    assert_eq!(result, expected);`, invocation, call.Function, rustRawString(jsonText(call.Expected)))
+	}
+
+	return fmt.Sprintf("%sfn %s() {\n%s\n}", header, name, body), nil
+}
+
+// rustString returns s as an escaped Rust string literal
+func rustString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u{%x}`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// rustRawString returns s as a raw Rust string literal with enough # delimiters
+func rustRawString(s string) string {
+	hashes := "#"
+	for strings.Contains(s, `"`+hashes) {
+		hashes += "#"
+	}
+	return "r" + hashes + `"` + s + `"` + hashes
+}
+
+// rustSlice returns strs as a Rust slice of string literals
+func rustSlice(strs []string) string {
+	items := make([]string, len(strs))
+	for i, s := range strs {
+		items[i] = rustString(s)
+	}
+	return "&[" + strings.Join(items, ", ") + "]"
+}
+
+// jsonText encodes a value as compact JSON with sorted object keys
+func jsonText(value interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "null"
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
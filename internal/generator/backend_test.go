@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestBackends_GenerateTest(t *testing.T) {
+	parseTest := types.TestCase{
+		Name:       "basic_parse",
+		Inputs:     []string{"key = value"},
+		Validation: "parse",
+		Expected:   []interface{}{map[string]interface{}{"key": "key", "value": "value"}},
+		Functions:  []string{"parse"},
+	}
+	listTest := types.TestCase{
+		Name:       "missing_list",
+		Inputs:     []string{"a = 1"},
+		Validation: "get_list",
+		Expected:   map[string]interface{}{"count": float64(0)},
+		Args:       []string{"missing"},
+	}
+	composeTest := types.TestCase{
+		Name:       "compose_two",
+		Inputs:     []string{"a = 1", "b = 2"},
+		Validation: "compose",
+		Expected:   map[string]interface{}{"count": float64(0)},
+	}
+
+	tests := []struct {
+		target   string
+		test     types.TestCase
+		expected []string
+	}{
+		{"pytest", parseTest, []string{"def test_basic_parse():", `ccl.parse("key = value")`, `assert result == [{"key": "key", "value": "value"}]`}},
+		{"pytest", listTest, []string{`ccl.get_list("a = 1", ["missing"])`, "except Exception:", `assert result in [None, False, 0, "", [], {}]`}},
+		{"jest", parseTest, []string{`test("basic_parse", () => {`, `expect(result).toEqual([{"key": "key", "value": "value"}]);`}},
+		{"jest", composeTest, []string{`ccl.compose(["a = 1", "b = 2"])`, "toEqual([])"}},
+		{"rust", parseTest, []string{"#[test]\nfn basic_parse() {", `ccl_adapter::parse("key = value")`, `r#"[{"key":"key","value":"value"}]"#`}},
+		{"rust", listTest, []string{`if let Ok(result) = ccl_adapter::get_list("a = 1", &["missing"])`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target+"/"+tt.test.Name, func(t *testing.T) {
+			backend, err := NewBackend(tt.target)
+			if err != nil {
+				t.Fatalf("NewBackend failed: %v", err)
+			}
+			code, err := backend.GenerateTest(tt.test, "")
+			if err != nil {
+				t.Fatalf("GenerateTest failed: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(code, want) {
+					t.Errorf("Expected generated code to contain %q, got:\n%s", want, code)
+				}
+			}
+		})
+	}
+}
+
+func TestBackends_SkippedTest(t *testing.T) {
+	test := types.TestCase{Name: "skipped", Inputs: []string{"a = 1"}, Validation: "parse", Expected: []interface{}{}}
+	expected := map[string]string{
+		"go":     `t.Skip("not supported")`,
+		"pytest": `@pytest.mark.skip(reason="not supported")`,
+		"jest":   `test.skip("skipped"`,
+		"rust":   `#[ignore = "not supported"]`,
+	}
+
+	for _, target := range Targets() {
+		backend, err := NewBackend(target)
+		if err != nil {
+			t.Fatalf("NewBackend(%s) failed: %v", target, err)
+		}
+		code, err := backend.GenerateTest(test, "not supported")
+		if err != nil {
+			t.Fatalf("%s: GenerateTest failed: %v", target, err)
+		}
+		if !strings.Contains(code, expected[target]) {
+			t.Errorf("%s: expected skipped test to contain %q, got:\n%s", target, expected[target], code)
+		}
+	}
+}
+
+func TestNewBackend_UnknownTarget(t *testing.T) {
+	if _, err := NewBackend("cobol"); err == nil {
+		t.Error("Expected error for unknown target")
+	}
+}
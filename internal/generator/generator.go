@@ -3,12 +3,14 @@
 // This package transforms JSON test suites into executable Go test files, supporting
 // feature-based tagging, object pooling for performance, and comprehensive assertion
 // tracking. The generator organizes tests by CCL functions and features
-// (parsing, comments, objects, etc.).
+// (parsing, comments, objects, etc.). Backends render the same tests for pytest,
+// Jest/Vitest and Rust.
 //
 // Key Features:
 //   - Feature-based test selection via structured tagging
 //   - Object pooling to reduce memory allocations during generation
 //   - Template-based Go test file generation with proper package organization
+//   - Pluggable backends for other languages' test frameworks
 //   - Assertion counting and statistics collection for test suite analysis
 //   - Support for mock implementation development with selective test generation
 //
@@ -59,7 +61,9 @@ type Generator struct {
 	options   Options
 	config    *config.RunnerConfig // Centralized configuration with behavioral choices
 	stats     AssertionStats
-	pool      *Pool // Object pool for memory optimization
+	pool      *Pool   // Object pool for memory optimization
+	backend   Backend // Target language the tests are rendered in
+	style     Style   // Layout of generated Go tests
 }
 
 // New creates a new generator instance with default options and configuration
@@ -76,7 +80,9 @@ func New(inputDir, outputDir string) *Generator {
 		stats: AssertionStats{
 			TestCounts: make(map[string]int),
 		},
-		pool: NewPool(),
+		pool:    NewPool(),
+		backend: &goBackend{},
+		style:   StyleFunctions,
 	}
 }
 
@@ -100,7 +106,9 @@ func NewWithConfig(inputDir, outputDir string, cfg *config.RunnerConfig) (*Gener
 		stats: AssertionStats{
 			TestCounts: make(map[string]int),
 		},
-		pool: NewPool(),
+		pool:    NewPool(),
+		backend: &goBackend{},
+		style:   StyleFunctions,
 	}, nil
 }
 
@@ -116,10 +124,24 @@ func NewWithOptions(inputDir, outputDir string, options Options) *Generator {
 		stats: AssertionStats{
 			TestCounts: make(map[string]int),
 		},
-		pool: NewPool(),
+		pool:    NewPool(),
+		backend: &goBackend{},
+		style:   StyleFunctions,
 	}
 }
 
+// WithBackend sets the backend used to render test files, replacing the default Go backend
+func (g *Generator) WithBackend(backend Backend) *Generator {
+	g.backend = backend
+	return g
+}
+
+// WithStyle sets the layout of generated Go tests
+func (g *Generator) WithStyle(style Style) *Generator {
+	g.style = style
+	return g
+}
+
 // GetStats returns the assertion statistics
 func (g *Generator) GetStats() AssertionStats {
 	return g.stats
@@ -127,6 +149,16 @@ func (g *Generator) GetStats() AssertionStats {
 
 // GenerateAll generates test files for all JSON test suites
 func (g *Generator) GenerateAll() error {
+	if g.style == StyleTable {
+		backend, ok := g.backend.(*goBackend)
+		if !ok {
+			return fmt.Errorf("table style is only supported for the go target, not %s", g.backend.Name())
+		}
+		if backend.templates != nil {
+			return fmt.Errorf("table style does not support template overrides")
+		}
+	}
+
 	// Ensure output directory exists
 	if err := os.MkdirAll(g.outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
@@ -140,6 +172,10 @@ func (g *Generator) GenerateAll() error {
 
 	styles.InfoLite("Found %d test files to process", len(testFiles))
 
+	if g.style == StyleTable {
+		return g.generateTables(testFiles)
+	}
+
 	// Process each test file
 	for _, file := range testFiles {
 		if err := g.generateTestFile(file); err != nil {
@@ -176,6 +212,33 @@ func (g *Generator) findTestFiles() ([]string, error) {
 
 // generateTestFile generates a Go test file from a flat format JSON test file
 func (g *Generator) generateTestFile(jsonFile string) error {
+	testSuite, err := g.loadTestSuite(jsonFile)
+	if err != nil {
+		return err
+	}
+
+	// Generate test file content
+	testContent, err := g.generateTestContent(*testSuite, jsonFile)
+	if err != nil {
+		return fmt.Errorf("failed to generate test content for %s: %w", filepath.Base(jsonFile), err)
+	}
+
+	// Determine output file path
+	outputPath := g.getOutputPath(*testSuite, jsonFile)
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", filepath.Dir(outputPath), err)
+	}
+
+	// Write test file
+	if err := os.WriteFile(outputPath, []byte(testContent), 0644); err != nil {
+		return fmt.Errorf("failed to write test file %s: %w", outputPath, err)
+	}
+
+	return nil
+}
+
+// loadTestSuite loads a flat format JSON test file, keeping the tests selected by the generator options
+func (g *Generator) loadTestSuite(jsonFile string) (*types.TestSuite, error) {
 	// Convert centralized config to ccl-test-lib format
 	impl := g.config.ToImplementationConfig()
 
@@ -224,27 +287,10 @@ func (g *Generator) generateTestFile(jsonFile string) error {
 		CustomFilter: customFilter,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to load flat format test file %s: %w", jsonFile, err)
-	}
-
-	// Generate test file content
-	testContent, err := g.generateTestContent(*testSuite, jsonFile)
-	if err != nil {
-		return fmt.Errorf("failed to generate test content for %s: %w", filepath.Base(jsonFile), err)
+		return nil, fmt.Errorf("failed to load flat format test file %s: %w", jsonFile, err)
 	}
 
-	// Determine output file path
-	outputPath := g.getOutputPath(*testSuite, jsonFile)
-	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
-		return fmt.Errorf("failed to create output directory %s: %w", filepath.Dir(outputPath), err)
-	}
-
-	// Write test file
-	if err := os.WriteFile(outputPath, []byte(testContent), 0644); err != nil {
-		return fmt.Errorf("failed to write test file %s: %w", outputPath, err)
-	}
-
-	return nil
+	return testSuite, nil
 }
 
 // generateTestContent creates the Go test file content
@@ -263,7 +309,7 @@ func (g *Generator) getOutputPath(testSuite types.TestSuite, sourceFile string)
 	// Create directory structure: feature/
 	dirName := strings.ReplaceAll(feature, "-", "_")
 
-	return filepath.Join(g.outputDir, dirName, baseName+"_test.go")
+	return filepath.Join(g.outputDir, g.backend.OutputPath(dirName, baseName))
 }
 
 // getPackageName generates the package name for the test file
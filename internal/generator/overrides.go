@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Template override files within a --templates directory. Any of them may be omitted,
+// in which case the built-in template is used.
+const (
+	FileTemplateFile       = "file.tmpl"   // Whole test file, executed with TemplateData
+	TestTemplateFile       = "test.tmpl"   // One test function, executed with TestCaseData
+	ValidationTemplatesDir = "validations" // <function>.tmpl per CCL function, executed with ValidationData
+)
+
+// ValidationData holds data for a per-validation template. Expected is normalized
+// from the flat format's structured expectation:
+//   - parse, parse_indented, filter, compose, expand_dotted: a list of {"key", "value"} maps
+//   - build_hierarchy: a nested map
+//   - get_*: the typed value, or a list of strings for get_list
+//   - canonical_format, round_trip and the algebraic properties: a string or bool
+type ValidationData struct {
+	Name         string      // Flat test name
+	Validation   string      // CCL function under test, e.g. "parse" or "get_int"
+	Inputs       []string    // CCL input text(s)
+	InputVars    []string    // Go variables declared for each input: "input", or "input0", "input1", ...
+	Args         []string    // Arguments for the function, such as a typed access key path
+	Expected     interface{} // Normalized expected value
+	ExpectError  bool        // True if the function must fail
+	ErrorOrEmpty bool        // True if either an error or an empty value is accepted (count-only typed access)
+}
+
+// Templates holds user-supplied template overrides loaded by LoadTemplates
+type Templates struct {
+	file        *template.Template
+	test        *template.Template
+	validations map[string]*template.Template
+}
+
+// TemplateFuncs returns the functions available to override templates
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"goString": escapeGoString,       // Go string literal, raw when possible
+		"quote":    strconv.Quote,        // Double-quoted Go string literal
+		"goValue":  formatGoValue,        // Go literal for a JSON value (maps, slices, scalars)
+		"goArgs":   formatArgs,           // []string literal
+		"pascal":   toPascalCase,         // snake_case to PascalCase
+		"join":     strings.Join,         // Join strings with a separator
+		"json":     jsonText,             // Compact JSON encoding
+		"indent":   indentTemplateOutput, // Indent every line after the first
+	}
+}
+
+// LoadTemplates reads template overrides from dir
+func LoadTemplates(dir string) (*Templates, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("templates path %s is not a directory", dir)
+	}
+
+	t := &Templates{validations: make(map[string]*template.Template)}
+
+	if t.file, err = loadTemplate(filepath.Join(dir, FileTemplateFile)); err != nil {
+		return nil, err
+	}
+	if t.test, err = loadTemplate(filepath.Join(dir, TestTemplateFile)); err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, ValidationTemplatesDir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find validation templates: %w", err)
+	}
+	for _, file := range files {
+		tmpl, err := loadTemplate(file)
+		if err != nil {
+			return nil, err
+		}
+		t.validations[strings.TrimSuffix(filepath.Base(file), ".tmpl")] = tmpl
+	}
+
+	if t.file == nil && t.test == nil && len(t.validations) == 0 {
+		return nil, fmt.Errorf("no templates found in %s (expected %s, %s or %s/<function>.tmpl)",
+			dir, FileTemplateFile, TestTemplateFile, ValidationTemplatesDir)
+	}
+
+	return t, nil
+}
+
+// loadTemplate parses a template file, returning nil if it does not exist
+func loadTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(TemplateFuncs()).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// Override returns a backend that renders with these templates. Only the Go backend
+// is template-based, so other targets are rejected.
+func (t *Templates) Override(backend Backend) (Backend, error) {
+	if _, ok := backend.(*goBackend); !ok {
+		return nil, fmt.Errorf("template overrides are only supported for the go target, not %s", backend.Name())
+	}
+	return &goBackend{templates: t}, nil
+}
+
+// fileTemplate returns the file template override, or nil
+func (t *Templates) fileTemplate() *template.Template {
+	if t == nil {
+		return nil
+	}
+	return t.file
+}
+
+// testTemplate returns the test case template override, or nil
+func (t *Templates) testTemplate() *template.Template {
+	if t == nil {
+		return nil
+	}
+	return t.test
+}
+
+// renderValidation renders the validation template for a test's function. It returns
+// an empty string if no template overrides that function.
+func (t *Templates) renderValidation(test types.TestCase) (string, error) {
+	if t == nil {
+		return "", nil
+	}
+	tmpl, ok := t.validations[test.Validation]
+	if !ok {
+		return "", nil
+	}
+
+	expected, expectError, errorOrEmpty := implementation.FlatExpectation(test)
+	data := ValidationData{
+		Name:         test.Name,
+		Validation:   test.Validation,
+		Inputs:       test.Inputs,
+		InputVars:    inputVars(test.Inputs),
+		Args:         test.Args,
+		Expected:     expected,
+		ExpectError:  expectError,
+		ErrorOrEmpty: errorOrEmpty,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute validation template for %s: %w", test.Name, err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// inputVars returns the names of the input variables declared by the test case template
+func inputVars(inputs []string) []string {
+	if len(inputs) == 1 {
+		return []string{"input"}
+	}
+	vars := make([]string, len(inputs))
+	for i := range inputs {
+		vars[i] = fmt.Sprintf("input%d", i)
+	}
+	return vars
+}
+
+// indentTemplateOutput indents every line of s after the first with prefix
+func indentTemplateOutput(prefix, s string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
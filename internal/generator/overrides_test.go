@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// exampleTemplatesDir holds the house-style templates documented in docs/generator-templates.md
+const exampleTemplatesDir = "../../docs/examples/templates"
+
+func TestTemplates_OverrideGoBackend(t *testing.T) {
+	templates, err := LoadTemplates(exampleTemplatesDir)
+	if err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	backend, err := templates.Override(&goBackend{})
+	if err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+
+	parseTest := types.TestCase{
+		Name:       "basic_parse",
+		Inputs:     []string{"a = 1"},
+		Validation: "parse",
+		Expected: map[string]interface{}{
+			"count":   float64(1),
+			"entries": []interface{}{map[string]interface{}{"key": "a", "value": "1"}},
+		},
+	}
+	code, err := backend.GenerateTest(parseTest, "")
+	if err != nil {
+		t.Fatalf("GenerateTest failed: %v", err)
+	}
+	for _, want := range []string{"func TestBasicParse(t *testing.T) {", "input := `a = 1`", "got, err := ccl.Parse(input)", `{Key: "a", Value: "1"},`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Expected generated code to contain %q, got:\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "assert.") {
+		t.Errorf("Expected parse template to replace testify assertions, got:\n%s", code)
+	}
+
+	// Functions without a validation template keep the built-in validation code
+	hierarchyTest := types.TestCase{
+		Name:       "basic_hierarchy",
+		Inputs:     []string{"a = 1"},
+		Validation: "build_hierarchy",
+		Expected:   map[string]interface{}{"a": "1"},
+	}
+	code, err = backend.GenerateTest(hierarchyTest, "")
+	if err != nil {
+		t.Fatalf("GenerateTest failed: %v", err)
+	}
+	if !strings.Contains(code, "ccl.BuildHierarchy(parseResult)") {
+		t.Errorf("Expected built-in build_hierarchy validation, got:\n%s", code)
+	}
+}
+
+func TestTemplates_OverrideRejectsOtherTargets(t *testing.T) {
+	templates, err := LoadTemplates(exampleTemplatesDir)
+	if err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if _, err := templates.Override(&pytestBackend{}); err == nil {
+		t.Error("Expected error overriding the pytest backend")
+	}
+}
+
+func TestLoadTemplates_EmptyDirectory(t *testing.T) {
+	if _, err := LoadTemplates(t.TempDir()); err == nil {
+		t.Error("Expected error for a directory without templates")
+	}
+}
@@ -0,0 +1,397 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/catconflang/ccl-test-data/internal/styles"
+)
+
+// Style selects how generated Go tests are laid out
+type Style string
+
+const (
+	StyleFunctions Style = "functions" // One test function per flat test (default)
+	StyleTable     Style = "table"     // One table-driven test per CCL function per package
+)
+
+// Styles returns the names of all output styles
+func Styles() []string {
+	return []string{string(StyleFunctions), string(StyleTable)}
+}
+
+// ParseStyle returns the style for a name selected with generate --style
+func ParseStyle(name string) (Style, error) {
+	for _, style := range Styles() {
+		if name == style {
+			return Style(name), nil
+		}
+	}
+	return "", fmt.Errorf("unknown style %q (available: %s)", name, strings.Join(Styles(), ", "))
+}
+
+const tableFileTemplate = `package {{.PackageName}}_test
+
+import (
+	"testing"
+{{if .Kind.WantType}}
+	"github.com/catconflang/ccl-test-data/internal/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+{{end}})
+
+// Generated from {{join .SourceFiles ", "}}
+// Function: {{.Function}}
+
+func Test{{.TestFuncName}}(t *testing.T) {
+	tests := []struct {
+		name   string
+		skip   string
+		inputs []string
+{{- if .Kind.Path}}
+		path []string
+{{- end}}
+{{- if .Kind.WantType}}
+		want    {{.Kind.WantType}}
+		wantErr bool
+{{- end}}
+{{- if .Kind.Path}}
+		errorOrEmpty bool
+{{- end}}
+	}{
+{{- range .Rows}}
+		// {{.Comment}}
+		{{.Literal}},
+{{- end}}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.skip != "" {
+				t.Skip(tt.skip)
+			}
+{{.Kind.Body}}
+		})
+	}
+}
+`
+
+// tableKind describes the table-driven test for one CCL function: the type of its
+// expected value and the subtest body shared by every row
+type tableKind struct {
+	WantType string // Go type of the want field, empty for functions without validations
+	Path     bool   // Rows carry a typed access key path
+	Body     string // Subtest body, run with tt bound to the row
+}
+
+// tableRow is one rendered row of a table
+type tableRow struct {
+	Comment string // Test name and tags
+	Literal string // Go composite literal for the row
+}
+
+// testTable collects the rows of one table-driven test while source files are loaded
+type testTable struct {
+	PackageName  string
+	Function     string
+	TestFuncName string
+	SourceFiles  []string
+	Kind         tableKind
+	Rows         []tableRow
+}
+
+// parseStep parses the first input, returning early when a parse error is expected
+const parseStep = `			ccl := mock.New()
+			parseResult, err := ccl.Parse(tt.inputs[0])
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+`
+
+// composeStep parses every input and composes the results in order, returning early
+// when a parse error is expected
+const composeStep = `			ccl := mock.New()
+			var parseErrors []error
+			var parseResult []mock.Entry
+			for i, input := range tt.inputs {
+				entries, err := ccl.Parse(input)
+				if err != nil {
+					parseErrors = append(parseErrors, err)
+				} else if i == 0 {
+					parseResult = entries
+				} else {
+					parseResult = ccl.Compose(parseResult, entries)
+				}
+			}
+			if tt.wantErr {
+				require.NotEmpty(t, parseErrors)
+				return
+			}
+			require.Empty(t, parseErrors)
+`
+
+// assertEntriesStep compares entries, treating an empty expectation as any empty result
+const assertEntriesStep = `			if len(tt.want) == 0 {
+				assert.Empty(t, result)
+			} else {
+				assert.Equal(t, tt.want, result)
+			}`
+
+// newTableKind returns the table layout for a CCL function, as named by newAdapterCall
+func newTableKind(function string) tableKind {
+	method := toPascalCase(function)
+
+	switch function {
+	case "parse":
+		return tableKind{WantType: "[]mock.Entry", Body: parseStep + `			assert.Equal(t, tt.want, parseResult)`}
+	case "filter":
+		return tableKind{WantType: "[]mock.Entry", Body: composeStep + `			result := ccl.Filter(parseResult)
+` + assertEntriesStep}
+	case "compose":
+		return tableKind{WantType: "[]mock.Entry", Body: composeStep + `			result := parseResult
+` + assertEntriesStep}
+	case "build_hierarchy":
+		return tableKind{WantType: "map[string]interface{}", Body: parseStep + `			assert.Equal(t, tt.want, ccl.BuildHierarchy(parseResult))`}
+	case "get_string", "get_int", "get_bool", "get_float", "get_list":
+		return tableKind{WantType: typedAccessWantTypes[function], Path: true, Body: fmt.Sprintf(`			ccl := mock.New()
+			parseResult, err := ccl.Parse(tt.inputs[0])
+			require.NoError(t, err)
+			hierarchy := ccl.BuildHierarchy(parseResult)
+			result, err := ccl.%s(hierarchy, tt.path)
+			switch {
+			case tt.wantErr:
+				require.Error(t, err)
+			case tt.errorOrEmpty:
+				if err == nil {
+					assert.Empty(t, result)
+				}
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, result)
+			}`, method)}
+	case "print":
+		return tableKind{WantType: "string", Body: parseStep + `			assert.Equal(t, tt.want, ccl.Print(parseResult))`}
+	case "canonical_format", "pretty_print":
+		return tableKind{WantType: "string", Body: parseStep + `			hierarchy := ccl.BuildHierarchy(parseResult)
+			assert.Equal(t, tt.want, ccl.PrettyPrint(hierarchy))`}
+	case "round_trip":
+		return tableKind{WantType: "bool", Body: `			ccl := mock.New()
+			roundTripResult, err := ccl.RoundTrip(tt.inputs[0])
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, roundTripResult)`}
+	case "compose_associative", "identity_left", "identity_right":
+		return tableKind{WantType: "bool", Body: fmt.Sprintf(`			ccl := mock.New()
+			propertyHolds, err := ccl.%s(tt.inputs)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, propertyHolds)`, method)}
+	default:
+		return tableKind{Body: fmt.Sprintf("\t\t\t// TODO: Implement %s validation", function)}
+	}
+}
+
+// typedAccessWantTypes maps typed access functions to the Go type they return
+var typedAccessWantTypes = map[string]string{
+	"get_string": "string",
+	"get_int":    "int",
+	"get_bool":   "bool",
+	"get_float":  "float64",
+	"get_list":   "[]string",
+}
+
+// generateTables renders every flat test as a row of a table-driven test. Rows are
+// grouped by package and CCL function across all source files.
+func (g *Generator) generateTables(testFiles []string) error {
+	tables := make(map[string]*testTable)
+
+	for _, file := range testFiles {
+		testSuite, err := g.loadTestSuite(file)
+		if err != nil {
+			return err
+		}
+		packageName := g.getPackageName(*testSuite)
+
+		for _, test := range testSuite.Tests {
+			isSkipped, skipReason := g.skipTest(test)
+			g.recordTest(test, isSkipped)
+
+			call, err := newAdapterCall(test)
+			if err != nil {
+				return fmt.Errorf("failed to generate test case %s: %w", test.Name, err)
+			}
+
+			key := packageName + "/" + call.Function
+			table, ok := tables[key]
+			if !ok {
+				table = &testTable{
+					PackageName:  packageName,
+					Function:     call.Function,
+					TestFuncName: toPascalCase(call.Function),
+					Kind:         newTableKind(call.Function),
+				}
+				tables[key] = table
+			}
+			if n := len(table.SourceFiles); n == 0 || table.SourceFiles[n-1] != file {
+				table.SourceFiles = append(table.SourceFiles, file)
+			}
+
+			row, err := formatTableRow(table.Kind, call, test.Name, skipReason)
+			if err != nil {
+				return fmt.Errorf("failed to generate test case %s: %w", test.Name, err)
+			}
+			table.Rows = append(table.Rows, tableRow{
+				Comment: fmt.Sprintf("%s - %s", test.Name, strings.Join(getTestTags(test), " ")),
+				Literal: row,
+			})
+		}
+		styles.FileProcessed(filepath.Base(file))
+	}
+
+	keys := make([]string, 0, len(tables))
+	for key := range tables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		table := tables[key]
+		content, err := generateTableFile(table)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s table: %w", key, err)
+		}
+
+		outputPath := filepath.Join(g.outputDir, table.PackageName, table.Function+"_table_test.go")
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory %s: %w", filepath.Dir(outputPath), err)
+		}
+		if err := os.WriteFile(outputPath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write test file %s: %w", outputPath, err)
+		}
+	}
+
+	return nil
+}
+
+// generateTableFile renders and formats a table-driven test file
+func generateTableFile(table *testTable) ([]byte, error) {
+	tmpl, err := template.New("table").Funcs(template.FuncMap{"join": strings.Join}).Parse(tableFileTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse table template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, table); err != nil {
+		return nil, fmt.Errorf("failed to execute table template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format table test: %w", err)
+	}
+	return formatted, nil
+}
+
+// formatTableRow renders the row literal for one test. Zero-valued fields are omitted.
+func formatTableRow(kind tableKind, call adapterCall, name, skipReason string) (string, error) {
+	inputs := make([]string, len(call.Inputs))
+	for i, input := range call.Inputs {
+		inputs[i] = escapeGoString(input)
+	}
+
+	fields := []string{"name: " + strconv.Quote(name)}
+	if skipReason != "" {
+		fields = append(fields, "skip: "+strconv.Quote(skipReason))
+	}
+	fields = append(fields, "inputs: []string{"+strings.Join(inputs, ", ")+"}")
+	if kind.Path {
+		fields = append(fields, "path: "+formatArgs(call.Path))
+	}
+
+	switch {
+	case kind.WantType == "":
+		// Functions without validations only record the test
+	case call.ExpectError:
+		fields = append(fields, "wantErr: true")
+	case call.ErrorOrEmpty:
+		fields = append(fields, "errorOrEmpty: true")
+	default:
+		want, err := formatTableWant(kind.WantType, call.Expected)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, "want: "+want)
+	}
+
+	return "{" + strings.Join(fields, ", ") + "}", nil
+}
+
+// formatTableWant renders a normalized expected value as a literal of the table's want type
+func formatTableWant(wantType string, expected interface{}) (string, error) {
+	switch wantType {
+	case "[]mock.Entry":
+		entries, ok := expected.([]interface{})
+		if !ok {
+			return "", fmt.Errorf("expected entries, got %T", expected)
+		}
+		goEntries := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			entryMap, _ := entry.(map[string]interface{})
+			key, _ := entryMap["key"].(string)
+			value, _ := entryMap["value"].(string)
+			goEntries = append(goEntries, fmt.Sprintf("{Key: %q, Value: %q}", key, value))
+		}
+		return "[]mock.Entry{" + strings.Join(goEntries, ", ") + "}", nil
+	case "map[string]interface{}":
+		object, ok := expected.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("expected object, got %T", expected)
+		}
+		return formatGoMap(object), nil
+	case "string":
+		s, ok := expected.(string)
+		if !ok {
+			return "", fmt.Errorf("expected string, got %T", expected)
+		}
+		return strconv.Quote(s), nil
+	case "bool":
+		b, ok := expected.(bool)
+		if !ok {
+			return "", fmt.Errorf("expected bool, got %T", expected)
+		}
+		return strconv.FormatBool(b), nil
+	case "int", "float64":
+		n, ok := expected.(float64)
+		if !ok {
+			return "", fmt.Errorf("expected number, got %T", expected)
+		}
+		return formatNumber(n), nil
+	case "[]string":
+		items, ok := expected.([]interface{})
+		if !ok {
+			return "", fmt.Errorf("expected list, got %T", expected)
+		}
+		strs := make([]string, len(items))
+		for i, item := range items {
+			strs[i] = fmt.Sprintf("%v", item)
+		}
+		return formatArgs(strs), nil
+	default:
+		return "", fmt.Errorf("unsupported table value type %s", wantType)
+	}
+}
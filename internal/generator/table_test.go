@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestGenerateTableFile(t *testing.T) {
+	tests := []struct {
+		test       types.TestCase
+		skipReason string
+		expected   []string
+	}{
+		{
+			test: types.TestCase{
+				Name:       "zero_values_build_hierarchy",
+				Inputs:     []string{"count = 0"},
+				Validation: "build_hierarchy",
+				Expected:   map[string]interface{}{"count": "0"},
+			},
+			expected: []string{
+				"func TestBuildHierarchy(t *testing.T) {",
+				`{name: "zero_values_build_hierarchy", inputs: []string{` + "`count = 0`" + `}, want: map[string]interface{}{"count": "0"}},`,
+				"assert.Equal(t, tt.want, ccl.BuildHierarchy(parseResult))",
+			},
+		},
+		{
+			test: types.TestCase{
+				Name:       "missing_float",
+				Inputs:     []string{"a = 1"},
+				Validation: "get_float",
+				Expected:   map[string]interface{}{"count": float64(1), "value": float64(3)},
+				Args:       []string{"a"},
+			},
+			skipReason: "not supported",
+			expected: []string{
+				"func TestGetFloat(t *testing.T) {",
+				`{name: "missing_float", skip: "not supported", inputs: []string{` + "`a = 1`" + `}, path: []string{"a"}, want: 3},`,
+				"result, err := ccl.GetFloat(hierarchy, tt.path)",
+				"t.Skip(tt.skip)",
+			},
+		},
+		{
+			test: types.TestCase{
+				Name:       "indented",
+				Inputs:     []string{"a = 1"},
+				Validation: "parse_indented",
+				Expected:   map[string]interface{}{"count": float64(0)},
+			},
+			expected: []string{
+				`{name: "indented", inputs: []string{` + "`a = 1`" + `}},`,
+				"// TODO: Implement parse_indented validation",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.test.Name, func(t *testing.T) {
+			call, err := newAdapterCall(tt.test)
+			if err != nil {
+				t.Fatalf("newAdapterCall() error = %v", err)
+			}
+			kind := newTableKind(call.Function)
+			row, err := formatTableRow(kind, call, tt.test.Name, tt.skipReason)
+			if err != nil {
+				t.Fatalf("formatTableRow() error = %v", err)
+			}
+
+			content, err := generateTableFile(&testTable{
+				PackageName:  "parsing",
+				Function:     call.Function,
+				TestFuncName: toPascalCase(call.Function),
+				SourceFiles:  []string{"generated_tests/example.json"},
+				Kind:         kind,
+				Rows:         []tableRow{{Comment: tt.test.Name, Literal: row}},
+			})
+			if err != nil {
+				t.Fatalf("generateTableFile() error = %v", err)
+			}
+
+			for _, want := range tt.expected {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("generated table missing %q:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
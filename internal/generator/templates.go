@@ -8,6 +8,8 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/loader"
 	"github.com/catconflang/ccl-test-data/types"
 )
 
@@ -75,6 +77,7 @@ type TestCaseData struct {
 	SkipReason        string
 	Inputs            []string // CCL input text(s) - single-input tests use 1-element array
 	InputStrings      []string // Escaped Go strings for each input
+	InputVars         []string // Go variable for each input: "input", or "input0", "input1", ...
 	HasInputs         bool     // True if inputs array exists and has elements
 	IsSingleInput     bool     // True if exactly one input (common case)
 	IsMultiInput      bool     // True if more than one input (algebraic tests)
@@ -83,9 +86,14 @@ type TestCaseData struct {
 	NeedsParseResult  bool
 	NeedsObjectResult bool
 	NeedsFilterResult bool
+	Tags              []string    // function:, feature:, behavior: and variant: tags
+	Validation        string      // CCL function under test
+	Args              []string    // Arguments for the function, such as a typed access key path
+	Expected          interface{} // Normalized expected value, see ValidationData
+	ExpectError       bool        // True if the function must fail
 }
 
-// generateTestContentFromTemplate creates Go test content using templates
+// generateTestContentFromTemplate creates test file content using the generator's backend
 func (g *Generator) generateTestContentFromTemplate(testSuite types.TestSuite, sourceFile string) (string, error) {
 	// Generate individual test cases
 	var testCases []string
@@ -93,27 +101,19 @@ func (g *Generator) generateTestContentFromTemplate(testSuite types.TestSuite, s
 	hasAssertions := false
 
 	for _, test := range testSuite.Tests {
-		testCase, err := g.generateTestCase(test)
+		// Check if this test is not skipped using generator options
+		// For flat format, use Functions field instead of Meta.Tags
+		isSkipped, skipReason := g.skipTest(test)
+
+		testCase, err := g.backend.GenerateTest(test, skipReason)
 		if err != nil {
 			return "", fmt.Errorf("failed to generate test case %s: %w", test.Name, err)
 		}
 		testCases = append(testCases, testCase)
 
-		// Count assertions and track statistics (flat format has 1 assertion per test)
-		assertionCount := 1 // Each flat test case is exactly 1 assertion
-		g.stats.TestCounts[test.Name] = assertionCount
-		g.stats.TotalTests++
-
-		// Check if this test is not skipped using generator options
-		// For flat format, use Functions field instead of Meta.Tags
-		tags := g.getTestTags(test)
-		isSkipped := g.shouldSkipTestByName(test.Name, tags)
-		if isSkipped {
-			g.stats.SkippedTests++
-			g.stats.SkippedAssertions += assertionCount
-		} else {
+		g.recordTest(test, isSkipped)
+		if !isSkipped {
 			hasActiveTests = true
-			g.stats.TotalAssertions += assertionCount
 			// Check if this test has implemented assertions (flat format always has assertions)
 			if test.Validation != "" {
 				hasAssertions = true
@@ -133,10 +133,32 @@ func (g *Generator) generateTestContentFromTemplate(testSuite types.TestSuite, s
 		HasAssertions:  hasAssertions,
 	}
 
-	// Execute template
-	tmpl, err := template.New("testfile").Parse(testFileTemplate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+	return g.backend.GenerateFile(data)
+}
+
+// recordTest counts a generated test and its assertions (flat format has 1 assertion per test)
+func (g *Generator) recordTest(test types.TestCase, isSkipped bool) {
+	assertionCount := 1 // Each flat test case is exactly 1 assertion
+	g.stats.TestCounts[test.Name] = assertionCount
+	g.stats.TotalTests++
+
+	if isSkipped {
+		g.stats.SkippedTests++
+		g.stats.SkippedAssertions += assertionCount
+	} else {
+		g.stats.TotalAssertions += assertionCount
+	}
+}
+
+// GenerateFile renders a Go test file from generated test cases
+func (b *goBackend) GenerateFile(data TemplateData) (string, error) {
+	tmpl := b.templates.fileTemplate()
+	if tmpl == nil {
+		var err error
+		tmpl, err = template.New("testfile").Parse(testFileTemplate)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse template: %w", err)
+		}
 	}
 
 	var buf bytes.Buffer
@@ -147,36 +169,45 @@ func (g *Generator) generateTestContentFromTemplate(testSuite types.TestSuite, s
 	return buf.String(), nil
 }
 
-// generateTestCase creates a single test case
-func (g *Generator) generateTestCase(test types.TestCase) (string, error) {
+// GenerateTest renders a single Go test function
+func (b *goBackend) GenerateTest(test types.TestCase, skipReason string) (string, error) {
 	// Build escaped input strings
 	inputStrings := make([]string, len(test.Inputs))
 	for i, input := range test.Inputs {
 		inputStrings[i] = escapeGoString(input)
 	}
 
+	expected, expectError, _ := implementation.FlatExpectation(test)
+	tags := getTestTags(test)
 	data := TestCaseData{
 		Name:          test.Name,
 		TestFuncName:  toPascalCase(test.Name),
-		TagsString:    strings.Join(g.getTestTags(test), " "),
+		TagsString:    strings.Join(tags, " "),
 		Inputs:        test.Inputs,
 		InputStrings:  inputStrings,
+		InputVars:     inputVars(test.Inputs),
 		HasInputs:     len(test.Inputs) > 0,
 		IsSingleInput: len(test.Inputs) == 1,
 		IsMultiInput:  len(test.Inputs) > 1,
+		ShouldSkip:    skipReason != "",
+		SkipReason:    skipReason,
+		Tags:          tags,
+		Validation:    test.Validation,
+		Args:          test.Args,
+		Expected:      expected,
+		ExpectError:   expectError,
 	}
 
-	// Check if test should be skipped using generator options
-	tags := g.getTestTags(test)
-	if g.shouldSkipTestByName(test.Name, tags) {
-		data.ShouldSkip = true
-		data.SkipReason = g.getSkipReasonByName(test.Name, tags)
-	}
-
-	// Generate actual validation for flat format
-	validation, err := g.generateFlatFormatValidation(test)
+	// Generate actual validation for flat format, preferring a user-supplied template
+	validation, err := b.templates.renderValidation(test)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate flat format validation: %w", err)
+		return "", err
+	}
+	if validation == "" {
+		validation, err = b.generateFlatFormatValidation(test)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate flat format validation: %w", err)
+		}
 	}
 	data.Validations = []string{validation}
 
@@ -189,9 +220,12 @@ func (g *Generator) generateTestCase(test types.TestCase) (string, error) {
 	data.NeedsFilterResult = false
 
 	// Execute template
-	tmpl, err := template.New("testcase").Parse(testCaseTemplate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse test case template: %w", err)
+	tmpl := b.templates.testTemplate()
+	if tmpl == nil {
+		tmpl, err = template.New("testcase").Parse(testCaseTemplate)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse test case template: %w", err)
+		}
 	}
 
 	var buf bytes.Buffer
@@ -588,14 +622,24 @@ func escapeGoString(s string) string {
 }
 
 // generateFlatFormatValidation creates validation code for flat format tests
-func (g *Generator) generateFlatFormatValidation(test types.TestCase) (string, error) {
+func (b *goBackend) generateFlatFormatValidation(test types.TestCase) (string, error) {
 	switch test.Validation {
 	case "parse":
-		return g.generateFlatParseValidation(test)
+		return b.generateFlatParseValidation(test)
 	case "build_hierarchy":
-		return g.generateFlatBuildHierarchyValidation(test)
+		return b.generateFlatBuildHierarchyValidation(test)
 	case "get_string", "get_int", "get_bool", "get_float", "get_list":
-		return g.generateFlatTypedAccessValidation(test, test.Validation)
+		return b.generateFlatTypedAccessValidation(test, test.Validation)
+	case "filter":
+		return b.generateFlatFilterValidation(test)
+	case "combine", "compose":
+		return b.generateFlatComposeValidation(test)
+	case "round_trip":
+		return b.generateFlatRoundTripValidation(test)
+	case "canonical_format", "pretty_print":
+		return b.generateFlatCanonicalFormatValidation(test)
+	case "compose_associative", "identity_left", "identity_right":
+		return b.generateFlatAlgebraicValidation(test)
 	default:
 		// For unimplemented validations, generate a safe comment with variable usage
 		// Handle both single-input and multi-input tests
@@ -617,7 +661,7 @@ func (g *Generator) generateFlatFormatValidation(test types.TestCase) (string, e
 }
 
 // generateFlatParseValidation generates parse validation for flat format
-func (g *Generator) generateFlatParseValidation(test types.TestCase) (string, error) {
+func (b *goBackend) generateFlatParseValidation(test types.TestCase) (string, error) {
 	// Handle case where Expected is directly an array of entries (loader returns this format)
 	if entriesArray, ok := test.Expected.([]interface{}); ok {
 		// Convert to Go-formatted entry array
@@ -693,7 +737,7 @@ func (g *Generator) generateFlatParseValidation(test types.TestCase) (string, er
 }
 
 // generateFlatBuildHierarchyValidation generates build_hierarchy validation for flat format
-func (g *Generator) generateFlatBuildHierarchyValidation(test types.TestCase) (string, error) {
+func (b *goBackend) generateFlatBuildHierarchyValidation(test types.TestCase) (string, error) {
 	// For build_hierarchy, expected is usually a nested object
 	expectedMap, ok := test.Expected.(map[string]interface{})
 	if !ok {
@@ -721,7 +765,7 @@ func (g *Generator) generateFlatBuildHierarchyValidation(test types.TestCase) (s
 }
 
 // generateFlatTypedAccessValidation generates typed access validation for flat format
-func (g *Generator) generateFlatTypedAccessValidation(test types.TestCase, validation string) (string, error) {
+func (b *goBackend) generateFlatTypedAccessValidation(test types.TestCase, validation string) (string, error) {
 	// Handle case where Expected is directly the value (loader returns this format for typed access)
 	if test.Expected != nil {
 		// Check if it's a simple value (string, int, bool, float, or array for lists)
@@ -733,7 +777,7 @@ func (g *Generator) generateFlatTypedAccessValidation(test types.TestCase, valid
 				args = []string{"title"} // Common default for typed access tests
 			}
 
-			return g.generateTypedAccessForDirectValue(validation, args, v)
+			return b.generateTypedAccessForDirectValue(validation, args, v)
 		}
 	}
 
@@ -882,7 +926,7 @@ func (g *Generator) generateFlatTypedAccessValidation(test types.TestCase, valid
 }
 
 // generateTypedAccessForDirectValue generates validation for when the expected value is returned directly
-func (g *Generator) generateTypedAccessForDirectValue(validation string, args []string, expectedValue interface{}) (string, error) {
+func (b *goBackend) generateTypedAccessForDirectValue(validation string, args []string, expectedValue interface{}) (string, error) {
 	var template string
 	switch validation {
 	case "get_string":
@@ -916,8 +960,194 @@ func (g *Generator) generateTypedAccessForDirectValue(validation string, args []
 %s`, validation, fmt.Sprintf(template, formatArgs(args), expectedValue)), nil
 }
 
+// generateFlatFilterValidation generates filter validation for flat format
+func (b *goBackend) generateFlatFilterValidation(test types.TestCase) (string, error) {
+	if test.ExpectError {
+		return generateFlatParseErrorValidation("Filter", test.Inputs), nil
+	}
+
+	expected, err := formatExpectedEntries("filter", test.Expected)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`// Filter validation
+	%s
+	filterResult := ccl.Filter(parseResult)
+	%s`, generateFlatParseSteps(test.Inputs, "parseResult"), assertEntries(expected, "filterResult")), nil
+}
+
+// generateFlatComposeValidation generates combine/compose validation for flat format.
+// Each input is parsed in its own step and the results are composed left to right.
+func (b *goBackend) generateFlatComposeValidation(test types.TestCase) (string, error) {
+	if len(test.Inputs) == 0 {
+		return "", fmt.Errorf("%s validation requires at least 1 input", test.Validation)
+	}
+
+	if test.ExpectError {
+		return generateFlatParseErrorValidation("Compose", test.Inputs), nil
+	}
+
+	expected, err := formatExpectedEntries(test.Validation, test.Expected)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`// Compose validation
+	%s
+	%s`, generateFlatParseSteps(test.Inputs, "composeResult"), assertEntries(expected, "composeResult")), nil
+}
+
+// generateFlatParseSteps parses each input in its own step. With several inputs the parsed
+// entries are composed in order, so resultVar always holds the entries of all inputs.
+func generateFlatParseSteps(inputs []string, resultVar string) string {
+	if len(inputs) == 1 {
+		return fmt.Sprintf(`%s, err := ccl.Parse(input)
+	require.NoError(t, err)`, resultVar)
+	}
+
+	if len(inputs) == 0 {
+		return fmt.Sprintf("var %s []mock.Entry", resultVar)
+	}
+
+	var steps []string
+	for i := range inputs {
+		steps = append(steps, fmt.Sprintf(`parse%d, err := ccl.Parse(input%d)
+	require.NoError(t, err)`, i, i))
+	}
+	steps = append(steps, fmt.Sprintf("%s := ccl.Compose(parse0, parse1)", resultVar))
+	for i := 2; i < len(inputs); i++ {
+		steps = append(steps, fmt.Sprintf("%s = ccl.Compose(%s, parse%d)", resultVar, resultVar, i))
+	}
+	return strings.Join(steps, "\n\t")
+}
+
+// generateFlatParseErrorValidation asserts that parsing at least one of the inputs fails
+func generateFlatParseErrorValidation(method string, inputs []string) string {
+	return fmt.Sprintf(`// %s validation (expects error)
+	var parseErrors []error
+	for _, in := range %s {
+		if _, err = ccl.Parse(in); err != nil {
+			parseErrors = append(parseErrors, err)
+		}
+	}
+	require.NotEmpty(t, parseErrors)`, method, formatInputVars(inputs))
+}
+
+// formatExpectedEntries converts an entries expectation to a []mock.Entry literal.
+// A count-only expectation (no entries field) means an empty result and returns "".
+func formatExpectedEntries(validation string, expected interface{}) (string, error) {
+	if expectedMap, ok := expected.(map[string]interface{}); ok {
+		entries, hasEntries := expectedMap["entries"]
+		if !hasEntries {
+			return "", nil
+		}
+		expected = entries
+	}
+
+	entriesArray, ok := expected.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("expected entries array for %s validation, got %T", validation, expected)
+	}
+	if len(entriesArray) == 0 {
+		return "", nil
+	}
+
+	var goEntries []string
+	for _, entry := range entriesArray {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := entryMap["key"].(string)
+		value, _ := entryMap["value"].(string)
+		goEntries = append(goEntries, fmt.Sprintf(`mock.Entry{Key: %q, Value: %q}`, key, value))
+	}
+	return "[]mock.Entry{" + strings.Join(goEntries, ", ") + "}", nil
+}
+
+// assertEntries compares resultVar against an entries literal from formatExpectedEntries
+func assertEntries(expected, resultVar string) string {
+	if expected == "" {
+		return fmt.Sprintf("assert.Empty(t, %s)", resultVar)
+	}
+	return fmt.Sprintf(`expected := %s
+	assert.Equal(t, expected, %s)`, expected, resultVar)
+}
+
+// generateFlatRoundTripValidation generates round_trip validation for flat format
+func (b *goBackend) generateFlatRoundTripValidation(test types.TestCase) (string, error) {
+	if len(test.Inputs) != 1 {
+		return "", fmt.Errorf("round_trip validation requires exactly 1 input, got %d", len(test.Inputs))
+	}
+
+	if test.ExpectError {
+		return `// RoundTrip validation (expects error)
+	_, err = ccl.RoundTrip(input)
+	require.Error(t, err)`, nil
+	}
+
+	switch expected := test.Expected.(type) {
+	case bool:
+		return fmt.Sprintf(`// RoundTrip validation
+	roundTripResult, err := ccl.RoundTrip(input)
+	require.NoError(t, err)
+	assert.Equal(t, %t, roundTripResult)`, expected), nil
+	case string:
+		// A string expectation is the normalized text produced by printing the parsed input
+		return fmt.Sprintf(`// RoundTrip validation
+	parseResult, err := ccl.Parse(input)
+	require.NoError(t, err)
+	printed := ccl.Print(parseResult)
+	assert.Equal(t, %q, printed)`, expected), nil
+	default:
+		return "", fmt.Errorf("expected bool or string for round_trip validation, got %T", test.Expected)
+	}
+}
+
+// generateFlatCanonicalFormatValidation generates canonical_format validation for flat format
+func (b *goBackend) generateFlatCanonicalFormatValidation(test types.TestCase) (string, error) {
+	if len(test.Inputs) != 1 {
+		return "", fmt.Errorf("%s validation requires exactly 1 input, got %d", test.Validation, len(test.Inputs))
+	}
+
+	expected, ok := test.Expected.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string for %s validation, got %T", test.Validation, test.Expected)
+	}
+
+	return fmt.Sprintf(`// %s validation
+	parseResult, err := ccl.Parse(input)
+	require.NoError(t, err)
+	hierarchy := ccl.BuildHierarchy(parseResult)
+	formatted := ccl.PrettyPrint(hierarchy)
+	assert.Equal(t, %q, formatted)`, test.Validation, expected), nil
+}
+
+// generateFlatAlgebraicValidation generates compose_associative, identity_left and
+// identity_right validations, which check a property across all of the test's inputs
+func (b *goBackend) generateFlatAlgebraicValidation(test types.TestCase) (string, error) {
+	method := toPascalCase(test.Validation)
+
+	if test.ExpectError {
+		return fmt.Sprintf(`// %s validation (expects error)
+	_, err = ccl.%s(%s)
+	require.Error(t, err)`, method, method, formatInputVars(test.Inputs)), nil
+	}
+
+	expected, ok := test.Expected.(bool)
+	if !ok {
+		return "", fmt.Errorf("expected bool for %s validation, got %T", test.Validation, test.Expected)
+	}
+
+	return fmt.Sprintf(`// %s validation
+	propertyHolds, err := ccl.%s(%s)
+	require.NoError(t, err)
+	assert.Equal(t, %t, propertyHolds)`, method, method, formatInputVars(test.Inputs), expected), nil
+}
+
 // getTestTags converts flat format test fields to structured tags for filtering
-func (g *Generator) getTestTags(test types.TestCase) []string {
+func getTestTags(test types.TestCase) []string {
 	var tags []string
 
 	// Convert Functions to function: tags
@@ -940,14 +1170,53 @@ func (g *Generator) getTestTags(test types.TestCase) []string {
 		tags = append(tags, "variant:"+variant)
 	}
 
+	// Tag tests outside the core tier so configurations can opt into them
+	if test.Tier != "" && test.Tier != "core" {
+		tags = append(tags, "tier:"+test.Tier)
+	}
+
 	// Also include any existing Meta.Tags
 	tags = append(tags, test.Meta.Tags...)
 
 	return tags
 }
 
+// skipTest determines if a test should be skipped, and why
+func (g *Generator) skipTest(test types.TestCase) (bool, string) {
+	if reason := g.versionSkipReason(test); reason != "" {
+		return true, reason
+	}
+
+	tags := getTestTags(test)
+	if g.shouldSkipTestByName(test.Name, tags) {
+		return true, g.getSkipReasonByName(test.Name, tags)
+	}
+	return false, ""
+}
+
+// versionSkipReason explains why a test added after the pinned corpus version is skipped
+func (g *Generator) versionSkipReason(test types.TestCase) string {
+	pinned := g.config.Implementation.CorpusVersion
+	if pinned == "" {
+		return ""
+	}
+	version, err := loader.ParseVersion(pinned)
+	if err != nil {
+		return "" // Rejected when the configuration is validated
+	}
+	if added, err := loader.AddedAfter(test, version); err != nil || !added {
+		return ""
+	}
+	return fmt.Sprintf("Added in corpus version %s, after pinned version %s", test.AddedIn, pinned)
+}
+
 // shouldSkipTest determines if a test should be skipped based on tags and generator options
 func (g *Generator) shouldSkipTest(tags []string) bool {
+	// Skip tests from tiers the configuration does not include
+	if g.excludedTier(tags) != "" {
+		return true
+	}
+
 	// If runOnly is specified, only run tests with those tags
 	if len(g.options.RunOnly) > 0 {
 		hasRunOnlyTag := false
@@ -1021,6 +1290,10 @@ func (g *Generator) shouldSkipTestByName(testName string, tags []string) bool {
 
 // getSkipReason determines the reason for skipping a test based on its tags and options
 func (g *Generator) getSkipReason(tags []string) string {
+	if tier := g.excludedTier(tags); tier != "" {
+		return fmt.Sprintf("Test tier not included: %s", tier)
+	}
+
 	// Check if skipped due to run-only filter
 	if len(g.options.RunOnly) > 0 {
 		hasRunOnlyTag := false
@@ -1069,6 +1342,16 @@ func (g *Generator) getSkipReason(tags []string) string {
 	return "Skipped test"
 }
 
+// excludedTier returns the tier named by a tier: tag if the configuration does not include it
+func (g *Generator) excludedTier(tags []string) string {
+	for _, tag := range tags {
+		if tier, ok := strings.CutPrefix(tag, "tier:"); ok && !g.config.IncludesTier(tier) {
+			return tier
+		}
+	}
+	return ""
+}
+
 // getSkipReasonByName determines the reason for skipping a test based on name, tags and options
 func (g *Generator) getSkipReasonByName(testName string, tags []string) string {
 	// Check if skipped by name first
@@ -1192,6 +1475,11 @@ func formatArgs(args []string) string {
 	return fmt.Sprintf("[]string{%s}", strings.Join(parts, ", "))
 }
 
+// formatInputVars returns a []string literal of the input variables declared by the test case template
+func formatInputVars(inputs []string) string {
+	return fmt.Sprintf("[]string{%s}", strings.Join(inputVars(inputs), ", "))
+}
+
 // Helper functions to determine which variables are needed
 
 func (g *Generator) needsParseResult(validations *types.ValidationSet) bool {
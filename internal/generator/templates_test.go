@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestGenerateFlatFormatValidation_PropertyTests(t *testing.T) {
+	tests := []struct {
+		name     string
+		test     types.TestCase
+		expected []string
+	}{
+		{
+			name:     "round_trip",
+			test:     types.TestCase{Validation: "round_trip", Inputs: []string{"key = value"}, Expected: true},
+			expected: []string{"ccl.RoundTrip(input)", "assert.Equal(t, true, roundTripResult)"},
+		},
+		{
+			name:     "round_trip text",
+			test:     types.TestCase{Validation: "round_trip", Inputs: []string{"key = value"}, Expected: "key = value"},
+			expected: []string{"ccl.Print(parseResult)", `assert.Equal(t, "key = value", printed)`},
+		},
+		{
+			name:     "canonical_format",
+			test:     types.TestCase{Validation: "canonical_format", Inputs: []string{"key = value"}, Expected: "key =\n  value =\n"},
+			expected: []string{"ccl.PrettyPrint(hierarchy)", `"key =\n  value =\n"`},
+		},
+		{
+			name:     "compose_associative",
+			test:     types.TestCase{Validation: "compose_associative", Inputs: []string{"a = 1", "b = 2", "c = 3"}, Expected: true},
+			expected: []string{"ccl.ComposeAssociative([]string{input0, input1, input2})"},
+		},
+		{
+			name:     "identity_left",
+			test:     types.TestCase{Validation: "identity_left", Inputs: []string{"", "a = 1"}, Expected: true},
+			expected: []string{"ccl.IdentityLeft([]string{input0, input1})"},
+		},
+		{
+			name:     "identity_right error",
+			test:     types.TestCase{Validation: "identity_right", Inputs: []string{"a = 1"}, ExpectError: true},
+			expected: []string{"ccl.IdentityRight([]string{input})", "require.Error(t, err)"},
+		},
+	}
+
+	b := &goBackend{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := b.generateFlatFormatValidation(tt.test)
+			if err != nil {
+				t.Fatalf("generateFlatFormatValidation failed: %v", err)
+			}
+			if strings.Contains(code, "TODO") {
+				t.Errorf("Expected %s to be implemented, got TODO:\n%s", tt.test.Validation, code)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(code, want) {
+					t.Errorf("Expected generated code to contain %q, got:\n%s", want, code)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFlatFormatValidation_FilterAndCompose(t *testing.T) {
+	entries := []interface{}{map[string]interface{}{"key": "a", "value": "1"}}
+
+	tests := []struct {
+		name     string
+		test     types.TestCase
+		expected []string
+	}{
+		{
+			name:     "filter",
+			test:     types.TestCase{Validation: "filter", Inputs: []string{"/= note\na = 1"}, Expected: entries},
+			expected: []string{"parseResult, err := ccl.Parse(input)", "filterResult := ccl.Filter(parseResult)", "assert.Equal(t, expected, filterResult)"},
+		},
+		{
+			name:     "filter count only",
+			test:     types.TestCase{Validation: "filter", Inputs: []string{"/= note"}, Expected: map[string]interface{}{"count": float64(0)}},
+			expected: []string{"assert.Empty(t, filterResult)"},
+		},
+		{
+			name: "compose",
+			test: types.TestCase{Validation: "compose", Inputs: []string{"a = 1", "b = 2", "c = 3"}, Expected: entries},
+			expected: []string{
+				"parse0, err := ccl.Parse(input0)",
+				"parse2, err := ccl.Parse(input2)",
+				"composeResult := ccl.Compose(parse0, parse1)",
+				"composeResult = ccl.Compose(composeResult, parse2)",
+			},
+		},
+		{
+			name:     "combine single input",
+			test:     types.TestCase{Validation: "combine", Inputs: []string{"a = 1"}, Expected: entries},
+			expected: []string{"composeResult, err := ccl.Parse(input)"},
+		},
+	}
+
+	b := &goBackend{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := b.generateFlatFormatValidation(tt.test)
+			if err != nil {
+				t.Fatalf("generateFlatFormatValidation failed: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(code, want) {
+					t.Errorf("Expected generated code to contain %q, got:\n%s", want, code)
+				}
+			}
+		})
+	}
+}
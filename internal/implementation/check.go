@@ -0,0 +1,193 @@
+package implementation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Status is the outcome of checking a flat test against an implementation
+type Status string
+
+const (
+	StatusPass        Status = "pass"
+	StatusFail        Status = "fail"        // The result differs from the expectation
+	StatusError       Status = "error"       // The function could not be evaluated at all
+	StatusUnsupported Status = "unsupported" // The implementation does not provide the function
+)
+
+// TestResult records how an implementation did on a single flat test
+type TestResult struct {
+	Name   string      `json:"name"`
+	Status Status      `json:"status"`
+	Actual interface{} `json:"actual,omitempty"` // Result value in source test "expect" shape
+	Error  string      `json:"error,omitempty"`  // Error reported by the function, or why it could not run
+}
+
+// Failed reports whether the test did not pass on a function the implementation supports
+func (r TestResult) Failed() bool {
+	return r.Status == StatusFail || r.Status == StatusError
+}
+
+// Report is the results file of a conformance run over flat tests:
+//
+//	{
+//	  "implementation": "ccl-rust 0.3.0",
+//	  "results": [
+//	    {"name": "basic_key_value_pairs_parse", "status": "pass"},
+//	    {"name": "just_key_error_parse", "status": "fail", "actual": [{"key": "key", "value": ""}]}
+//	  ]
+//	}
+type Report struct {
+	Implementation string       `json:"implementation"`
+	Results        []TestResult `json:"results"`
+}
+
+// LoadReport reads a results file
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %w", err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse results file %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// ByName indexes the results by test name
+func (r *Report) ByName() map[string]TestResult {
+	results := make(map[string]TestResult, len(r.Results))
+	for _, result := range r.Results {
+		results[result.Name] = result
+	}
+	return results
+}
+
+// Check runs a flat test against impl and compares the result with its expectation
+func Check(impl Implementation, test types.TestCase) TestResult {
+	expected, expectError, errorOrEmpty := FlatExpectation(test)
+
+	function := test.Validation
+	switch {
+	case function == "combine":
+		function = "compose"
+	case function == "round_trip":
+		// A round trip that expects text checks the printed form of the parsed input
+		if _, ok := expected.(string); ok {
+			function = "print"
+		}
+	}
+
+	result, err := impl.Run(function, test.Inputs, test.Args)
+	if errors.Is(err, ErrUnsupported) {
+		return TestResult{Name: test.Name, Status: StatusUnsupported, Error: err.Error()}
+	}
+	if err != nil {
+		return TestResult{Name: test.Name, Status: StatusError, Error: err.Error()}
+	}
+
+	outcome := TestResult{Name: test.Name, Status: StatusFail, Actual: result.Value, Error: result.Error}
+	switch {
+	case expectError:
+		if result.Error != "" {
+			outcome.Status = StatusPass
+		}
+	case result.Error != "":
+		if errorOrEmpty {
+			outcome.Status = StatusPass
+		}
+	case errorOrEmpty:
+		if isEmpty(result.Value) {
+			outcome.Status = StatusPass
+		}
+	case result.Matches(expected):
+		outcome.Status = StatusPass
+	}
+	return outcome
+}
+
+// FlatExpectation extracts the value a flat test expects, in the same shape as
+// Result.Value. Entry lists are reduced to {"key", "value"} objects. A count-only
+// expectation means an empty entry list, or for typed access, an error or empty value,
+// since the source corpus records typed access errors as null. The generator backends
+// use it too, so generated tests and Check agree on every expectation.
+func FlatExpectation(test types.TestCase) (expected interface{}, expectError, errorOrEmpty bool) {
+	if test.ExpectError {
+		return nil, true, false
+	}
+
+	expected = test.Expected
+	if expectedMap, ok := expected.(map[string]interface{}); ok {
+		// A hierarchy may have its own "count" key, but only the wrapper's count is a number
+		if _, hasCount := expectedMap["count"].(float64); hasCount {
+			if isError, _ := expectedMap["error"].(bool); isError {
+				return nil, true, false
+			}
+			field := ""
+			for _, name := range []string{"entries", "object", "value", "list"} {
+				if _, ok := expectedMap[name]; ok {
+					field = name
+					break
+				}
+			}
+			switch {
+			case field != "":
+				expected = expectedMap[field]
+			case strings.HasPrefix(test.Validation, "get_"):
+				return nil, false, true
+			default:
+				expected = []interface{}{}
+			}
+		}
+	}
+
+	if entries, ok := expected.([]interface{}); ok && ProducesEntries(test.Validation) {
+		normalized := make([]interface{}, 0, len(entries))
+		for _, entry := range entries {
+			entryMap, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key, _ := entryMap["key"].(string)
+			value, _ := entryMap["value"].(string)
+			normalized = append(normalized, map[string]interface{}{"key": key, "value": value})
+		}
+		expected = normalized
+	}
+
+	return expected, false, false
+}
+
+// ProducesEntries reports whether a CCL function returns a list of entries
+func ProducesEntries(function string) bool {
+	switch function {
+	case "parse", "parse_indented", "filter", "combine", "compose", "expand_dotted":
+		return true
+	}
+	return false
+}
+
+// EmptyResults are the values a count-only typed access expectation accepts in place
+// of an error. The generator backends write the same list into generated tests.
+var EmptyResults = []interface{}{nil, false, float64(0), "", []interface{}{}, map[string]interface{}{}}
+
+// isEmpty reports whether a result value is an empty result accepted in place of an error
+func isEmpty(value interface{}) bool {
+	normalized, err := Normalize(value)
+	if err != nil {
+		return false
+	}
+	for _, empty := range EmptyResults {
+		if reflect.DeepEqual(normalized, empty) {
+			return true
+		}
+	}
+	return false
+}
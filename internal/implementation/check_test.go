@@ -0,0 +1,71 @@
+package implementation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestCheck(t *testing.T) {
+	entries := map[string]interface{}{
+		"count":   float64(1),
+		"entries": []interface{}{map[string]interface{}{"key": "name", "value": "Alice"}},
+	}
+
+	tests := []struct {
+		name     string
+		test     types.TestCase
+		expected Status
+	}{
+		{
+			name:     "matching entries",
+			test:     types.TestCase{Validation: "parse", Inputs: []string{"name = Alice"}, Expected: entries},
+			expected: StatusPass,
+		},
+		{
+			name:     "different entries",
+			test:     types.TestCase{Validation: "parse", Inputs: []string{"name = Bob"}, Expected: entries},
+			expected: StatusFail,
+		},
+		{
+			name:     "count-only expectation accepts an error",
+			test:     types.TestCase{Validation: "get_int", Inputs: []string{"port = abc"}, Args: []string{"port"}, Expected: map[string]interface{}{"count": float64(1)}},
+			expected: StatusPass,
+		},
+		{
+			name:     "expected error",
+			test:     types.TestCase{Validation: "get_int", Inputs: []string{"port = 8080"}, Args: []string{"port"}, ExpectError: true},
+			expected: StatusFail,
+		},
+		{
+			name:     "unsupported function",
+			test:     types.TestCase{Validation: "frobnicate", Inputs: []string{"a = 1"}},
+			expected: StatusUnsupported,
+		},
+	}
+
+	impl := NewMock()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Check(impl, tt.test); result.Status != tt.expected {
+				t.Errorf("Check() status = %s, want %s (actual %v, error %q)", result.Status, tt.expected, result.Actual, result.Error)
+			}
+		})
+	}
+}
+
+func TestFlatExpectation_CountOnly(t *testing.T) {
+	countOnly := map[string]interface{}{"count": float64(0)}
+
+	// Entry functions expect an empty list, so an error fails them as in generated tests
+	expected, expectError, errorOrEmpty := FlatExpectation(types.TestCase{Validation: "parse", Expected: countOnly})
+	if !reflect.DeepEqual(expected, []interface{}{}) || expectError || errorOrEmpty {
+		t.Errorf("parse: FlatExpectation() = %v, %v, %v, want [], false, false", expected, expectError, errorOrEmpty)
+	}
+
+	expected, expectError, errorOrEmpty = FlatExpectation(types.TestCase{Validation: "get_string", Expected: countOnly})
+	if expected != nil || expectError || !errorOrEmpty {
+		t.Errorf("get_string: FlatExpectation() = %v, %v, %v, want nil, false, true", expected, expectError, errorOrEmpty)
+	}
+}
@@ -0,0 +1,81 @@
+package implementation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// External runs an implementation as a separate program, once per function call.
+//
+// The program receives a JSON request on stdin:
+//
+//	{"function": "get_int", "inputs": ["port = 8080"], "args": ["port"]}
+//
+// and must write a JSON response to stdout:
+//
+//	{"result": 8080}                          // success
+//	{"result": null, "error": "not a number"} // the function reported an error
+//	{"unsupported": true}                     // the function is not implemented
+//
+// A non-zero exit status is treated as a failure to evaluate the function.
+type External struct {
+	command []string
+}
+
+// externalRequest is the JSON document written to the program's stdin
+type externalRequest struct {
+	Function string   `json:"function"`
+	Inputs   []string `json:"inputs"`
+	Args     []string `json:"args,omitempty"`
+}
+
+// externalResponse is the JSON document read from the program's stdout
+type externalResponse struct {
+	Result      interface{} `json:"result"`
+	Error       string      `json:"error,omitempty"`
+	Unsupported bool        `json:"unsupported,omitempty"`
+}
+
+// NewExternal creates an Implementation that runs command for every function call
+func NewExternal(command []string) *External {
+	return &External{command: command}
+}
+
+// Name returns the command line used to run the implementation
+func (e *External) Name() string {
+	return strings.Join(e.command, " ")
+}
+
+// Run sends a single request to the external program and decodes its response
+func (e *External) Run(function string, inputs []string, args []string) (Result, error) {
+	request, err := json.Marshal(externalRequest{Function: function, Inputs: inputs, Args: args})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(e.command[0], e.command[1:]...)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return Result{}, fmt.Errorf("%s failed: %w: %s", e.Name(), err, msg)
+		}
+		return Result{}, fmt.Errorf("%s failed: %w", e.Name(), err)
+	}
+
+	var response externalResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return Result{}, fmt.Errorf("invalid response from %s: %w", e.Name(), err)
+	}
+	if response.Unsupported {
+		return Result{}, fmt.Errorf("%w: %s", ErrUnsupported, function)
+	}
+
+	return Result{Value: response.Result, Error: response.Error}, nil
+}
@@ -0,0 +1,93 @@
+// Package implementation runs CCL functions against an implementation under test.
+//
+// Commands that need real results for a test (such as snapshot) work with the
+// Implementation interface rather than a concrete parser. Two implementations
+// are provided:
+//   - Mock: the in-process reference implementation from internal/mock
+//   - External: any program speaking the JSON protocol described on External
+//
+// Results are returned in the same shape as the "expect" field of source tests,
+// so they can be compared with or written directly into source test files.
+package implementation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnsupported is returned when an implementation does not provide a function
+var ErrUnsupported = errors.New("function not supported")
+
+// Implementation evaluates CCL functions the way a source test validation describes them
+type Implementation interface {
+	// Name identifies the implementation in command output
+	Name() string
+	// Run evaluates function over the test inputs. Args carries the typed access path.
+	// A non-nil error means the function could not be evaluated at all; errors
+	// reported by the function itself are returned in Result.Error.
+	Run(function string, inputs []string, args []string) (Result, error)
+}
+
+// Result is the outcome of running a single function
+type Result struct {
+	Value interface{} `json:"result"`          // JSON-compatible value in source test "expect" shape
+	Error string      `json:"error,omitempty"` // Non-empty when the function reported an error
+}
+
+// Expect returns the value to record in a source test's "expect" field.
+// Error cases are recorded as null, matching the existing corpus.
+func (r Result) Expect() interface{} {
+	if r.Error != "" {
+		return nil
+	}
+	return r.Value
+}
+
+// Matches reports whether the result equals a decoded source test expectation.
+// Error results match a null expectation regardless of the error message.
+func (r Result) Matches(expect interface{}) bool {
+	actual, err := Normalize(r.Expect())
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(actual, expect)
+}
+
+// Equal reports whether two results would be recorded as the same expectation
+func (r Result) Equal(other Result) bool {
+	expect, err := Normalize(other.Expect())
+	if err != nil {
+		return false
+	}
+	return r.Matches(expect)
+}
+
+// Normalize round-trips a value through JSON so it compares equal to values decoded from test files
+func Normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("result is not JSON-compatible: %w", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// Open returns the implementation described by spec. The value "mock" selects the
+// built-in mock; anything else is treated as a command line for an External implementation.
+func Open(spec string) (Implementation, error) {
+	if spec == "" || spec == "mock" {
+		return NewMock(), nil
+	}
+
+	command := strings.Fields(spec)
+	if len(command) == 0 {
+		return nil, fmt.Errorf("empty implementation command")
+	}
+	return NewExternal(command), nil
+}
@@ -0,0 +1,157 @@
+package implementation
+
+import (
+	"fmt"
+
+	"github.com/catconflang/ccl-test-data/internal/mock"
+)
+
+// Mock adapts the internal mock CCL parser to the Implementation interface
+type Mock struct {
+	ccl *mock.CCL
+}
+
+// NewMock creates an Implementation backed by internal/mock
+func NewMock() *Mock {
+	return &Mock{ccl: mock.New()}
+}
+
+// Name returns the implementation name
+func (m *Mock) Name() string {
+	return "mock"
+}
+
+// Run evaluates function with the mock parser
+func (m *Mock) Run(function string, inputs []string, args []string) (Result, error) {
+	if len(inputs) == 0 {
+		return Result{}, fmt.Errorf("%s requires at least one input", function)
+	}
+
+	switch function {
+	case "parse":
+		return entriesResult(m.ccl.Parse(inputs[0]))
+	case "parse_indented":
+		return entriesResult(m.ccl.ParseIndented(inputs[0]))
+	case "filter":
+		entries, err := m.ccl.Parse(inputs[0])
+		if err != nil {
+			return errorResult(err), nil
+		}
+		return entriesResult(m.ccl.Filter(entries), nil)
+	case "expand_dotted":
+		entries, err := m.ccl.Parse(inputs[0])
+		if err != nil {
+			return errorResult(err), nil
+		}
+		return entriesResult(m.ccl.ExpandDotted(entries), nil)
+	case "combine", "compose":
+		var composed []mock.Entry
+		for _, input := range inputs {
+			entries, err := m.ccl.Parse(input)
+			if err != nil {
+				return errorResult(err), nil
+			}
+			composed = m.ccl.Compose(composed, entries)
+		}
+		return entriesResult(composed, nil)
+	case "print":
+		entries, err := m.ccl.Parse(inputs[0])
+		if err != nil {
+			return errorResult(err), nil
+		}
+		return Result{Value: m.ccl.Print(entries)}, nil
+	case "build_hierarchy", "load":
+		obj, err := m.hierarchy(inputs[0])
+		if err != nil {
+			return errorResult(err), nil
+		}
+		return Result{Value: obj}, nil
+	case "canonical_format", "pretty_print":
+		obj, err := m.hierarchy(inputs[0])
+		if err != nil {
+			return errorResult(err), nil
+		}
+		return Result{Value: m.ccl.PrettyPrint(obj)}, nil
+	case "get_string", "get_int", "get_bool", "get_float", "get_list":
+		obj, err := m.hierarchy(inputs[0])
+		if err != nil {
+			return errorResult(err), nil
+		}
+		return m.typedAccess(function, obj, args), nil
+	case "round_trip":
+		return boolResult(m.ccl.RoundTrip(inputs[0]))
+	case "compose_associative":
+		return boolResult(m.ccl.ComposeAssociative(inputs))
+	case "identity_left":
+		return boolResult(m.ccl.IdentityLeft(inputs))
+	case "identity_right":
+		return boolResult(m.ccl.IdentityRight(inputs))
+	}
+
+	return Result{}, fmt.Errorf("%w: %s", ErrUnsupported, function)
+}
+
+// hierarchy parses input and builds its object hierarchy
+func (m *Mock) hierarchy(input string) (map[string]interface{}, error) {
+	entries, err := m.ccl.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return m.ccl.BuildHierarchy(entries), nil
+}
+
+// typedAccess runs one of the get_* functions against obj
+func (m *Mock) typedAccess(function string, obj map[string]interface{}, path []string) Result {
+	var value interface{}
+	var err error
+
+	switch function {
+	case "get_string":
+		value, err = m.ccl.GetString(obj, path)
+	case "get_int":
+		value, err = m.ccl.GetInt(obj, path)
+	case "get_bool":
+		value, err = m.ccl.GetBool(obj, path)
+	case "get_float":
+		value, err = m.ccl.GetFloat(obj, path)
+	case "get_list":
+		var list []string
+		list, err = m.ccl.GetList(obj, path)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		value = items
+	}
+
+	if err != nil {
+		return errorResult(err)
+	}
+	return Result{Value: value}
+}
+
+// entriesResult converts parsed entries to the source format's list of key/value objects
+func entriesResult(entries []mock.Entry, err error) (Result, error) {
+	if err != nil {
+		return errorResult(err), nil
+	}
+
+	items := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		items[i] = map[string]interface{}{"key": entry.Key, "value": entry.Value}
+	}
+	return Result{Value: items}, nil
+}
+
+// boolResult wraps the outcome of an algebraic property check
+func boolResult(ok bool, err error) (Result, error) {
+	if err != nil {
+		return errorResult(err), nil
+	}
+	return Result{Value: ok}, nil
+}
+
+// errorResult records an error reported by the implementation
+func errorResult(err error) Result {
+	return Result{Error: err.Error()}
+}
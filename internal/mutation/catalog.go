@@ -0,0 +1,159 @@
+package mutation
+
+import "fmt"
+
+// Mutation is a single semantic change to the mock implementation's source.
+// Original must occur exactly once in the mock source; it is replaced by Replacement.
+type Mutation struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Functions   []string `json:"functions"` // CCL functions whose behavior the mutation changes
+	Original    string   `json:"-"`
+	Replacement string   `json:"-"`
+}
+
+// Catalog returns the built-in mutations of internal/mock/ccl.go.
+// Each one models a mistake a real implementation could plausibly make.
+func Catalog() []Mutation {
+	return []Mutation{
+		{
+			ID:          "no-trim-values",
+			Description: "Values keep surrounding whitespace",
+			Functions:   []string{"parse", "build_hierarchy", "get_string", "get_int", "get_bool", "get_float", "get_list"},
+			Original:    `value := strings.Trim(parts[1], " \t")`,
+			Replacement: `value := parts[1]`,
+		},
+		{
+			ID:          "no-trim-keys",
+			Description: "Keys keep surrounding whitespace",
+			Functions:   []string{"parse", "build_hierarchy", "get_string", "get_int", "get_bool", "get_float", "get_list"},
+			Original:    `key := strings.Trim(parts[0], " \t")`,
+			Replacement: `key := parts[0]`,
+		},
+		{
+			ID:          "no-comments",
+			Description: "Comment lines are parsed as ordinary entries",
+			Functions:   []string{"parse", "filter"},
+			Original:    `if strings.HasPrefix(strings.TrimSpace(line), "/=") {`,
+			Replacement: `if false {`,
+		},
+		{
+			ID:          "no-crlf-normalization",
+			Description: "CRLF line endings are not normalized",
+			Functions:   []string{"parse"},
+			Original:    `normalizedInput := strings.ReplaceAll(input, "\r\n", "\n")`,
+			Replacement: `normalizedInput := input`,
+		},
+		{
+			ID:          "blank-line-ends-value",
+			Description: "A blank line ends a multiline value",
+			Functions:   []string{"parse"},
+			Original: `} else if strings.TrimSpace(nextLine) == "" {
+							// Skip empty lines within multiline content
+							j++`,
+			Replacement: `} else if strings.TrimSpace(nextLine) == "" {
+							break`,
+		},
+		{
+			ID:          "drop-first-value-line",
+			Description: "The text on the key's line is dropped from multiline values",
+			Functions:   []string{"parse"},
+			Original:    `value = value + "\n" + strings.Join(multilineValue, "\n")`,
+			Replacement: `value = "\n" + strings.Join(multilineValue, "\n")`,
+		},
+		{
+			ID:          "filter-keeps-comments",
+			Description: "Filter keeps comment entries",
+			Functions:   []string{"filter"},
+			Original:    `if entry.Key != "/" {`,
+			Replacement: `if true {`,
+		},
+		{
+			ID:          "compose-swapped",
+			Description: "Compose concatenates right before left",
+			Functions:   []string{"combine", "compose_associative", "identity_left", "identity_right"},
+			Original: `copy(result, left)
+	copy(result[len(left):], right)`,
+			Replacement: `copy(result, right)
+	copy(result[len(right):], left)`,
+		},
+		{
+			ID:          "duplicate-keys-overwrite",
+			Description: "Duplicate keys overwrite instead of forming a list",
+			Functions:   []string{"build_hierarchy", "get_list"},
+			Original: `// Handle duplicate keys as lists
+			if existing, exists := result[key]; exists {`,
+			Replacement: `// Handle duplicate keys as lists
+			if existing, exists := result[key]; exists && false {`,
+		},
+		{
+			ID:          "single-empty-key-scalar",
+			Description: "A single empty-key item is stored as a scalar rather than a list",
+			Functions:   []string{"build_hierarchy", "get_list"},
+			Original:    `result[""] = []interface{}{value}`,
+			Replacement: `result[""] = value`,
+		},
+		{
+			ID:          "yes-is-false",
+			Description: "get_bool treats \"yes\" as false",
+			Functions:   []string{"get_bool"},
+			Original: `if str, ok := value.(string); ok {
+		return strconv.ParseBool(str)
+	}`,
+			Replacement: `if str, ok := value.(string); ok {
+		if str == "yes" {
+			return false, nil
+		}
+		return strconv.ParseBool(str)
+	}`,
+		},
+		{
+			ID:          "float-truncated",
+			Description: "get_float truncates to an integer",
+			Functions:   []string{"get_float"},
+			Original:    `return strconv.ParseFloat(str, 64)`,
+			Replacement: `f, err := strconv.ParseFloat(str, 64)
+		return float64(int64(f)), err`,
+		},
+		{
+			ID:          "print-no-spaces",
+			Description: "Print omits the spaces around '='",
+			Functions:   []string{"print", "round_trip"},
+			Original:    `sb.WriteString(" = ")`,
+			Replacement: `sb.WriteString("=")`,
+		},
+		{
+			ID:          "round-trip-always-true",
+			Description: "RoundTrip reports success without comparing",
+			Functions:   []string{"round_trip"},
+			Original:    `return entriesEqual(parsed, reparsed), nil`,
+			Replacement: `return len(reparsed) >= 0, nil`,
+		},
+	}
+}
+
+// Select returns the catalog mutations with the given IDs, in catalog order.
+// An empty list selects the whole catalog.
+func Select(ids []string) ([]Mutation, error) {
+	catalog := Catalog()
+	if len(ids) == 0 {
+		return catalog, nil
+	}
+
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	var selected []Mutation
+	for _, m := range catalog {
+		if wanted[m.ID] {
+			selected = append(selected, m)
+			delete(wanted, m.ID)
+		}
+	}
+	for id := range wanted {
+		return nil, fmt.Errorf("unknown mutation %q", id)
+	}
+	return selected, nil
+}
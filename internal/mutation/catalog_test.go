@@ -0,0 +1,36 @@
+package mutation
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestCatalog_AppliesToMock guards against the catalog drifting from the mock source
+func TestCatalog_AppliesToMock(t *testing.T) {
+	source, err := os.ReadFile("../mock/ccl.go")
+	if err != nil {
+		t.Fatalf("Failed to read mock source: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range Catalog() {
+		if seen[m.ID] {
+			t.Errorf("Duplicate mutation ID %s", m.ID)
+		}
+		seen[m.ID] = true
+
+		if count := strings.Count(string(source), m.Original); count != 1 {
+			t.Errorf("Mutation %s: original code found %d times in mock, expected 1", m.ID, count)
+		}
+		if len(m.Functions) == 0 {
+			t.Errorf("Mutation %s does not list affected functions", m.ID)
+		}
+	}
+}
+
+func TestSelect_UnknownMutation(t *testing.T) {
+	if _, err := Select([]string{"no-such-mutation"}); err == nil {
+		t.Error("Expected error for unknown mutation")
+	}
+}
@@ -0,0 +1,263 @@
+// Package mutation measures how well the generated test suite detects faults in
+// the mock CCL implementation.
+//
+// Each mutation in the catalog rewrites a small piece of internal/mock/ccl.go.
+// The generated Go tests are run against every mutant using the go command's
+// -overlay flag, so the working tree is never modified. A mutant is killed when
+// a test that passes against the unmodified mock fails against it; mutants that
+// survive point at behavior the suite does not pin down.
+package mutation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status is the outcome of running the test suite against a mutant
+type Status string
+
+const (
+	StatusKilled       Status = "killed"        // At least one previously passing test failed
+	StatusSurvived     Status = "survived"      // Every previously passing test still passed
+	StatusCompileError Status = "compile_error" // The mutant or tests did not build
+	StatusNotApplied   Status = "not_applied"   // The mutation's original code was not found exactly once
+)
+
+// Result records the outcome for a single mutation
+type Result struct {
+	Mutation
+	Status       Status        `json:"status"`
+	KillingTests []string      `json:"killing_tests,omitempty"`
+	Duration     time.Duration `json:"duration"`
+	Detail       string        `json:"detail,omitempty"`
+}
+
+// Report summarizes a mutation testing run
+type Report struct {
+	Packages       []string `json:"packages"`
+	BaselineFailed []string `json:"baseline_failed"` // Tests already failing against the unmodified mock
+	Results        []Result `json:"results"`
+}
+
+// Runner applies mutations to the mock source and runs the generated tests
+type Runner struct {
+	MockSource string   // Path to internal/mock/ccl.go
+	Packages   []string // Package patterns passed to go test, e.g. ./go_tests/...
+	Progress   func(result Result)
+}
+
+// NewRunner creates a runner for the given mock source and test packages
+func NewRunner(mockSource string, packages []string) *Runner {
+	return &Runner{
+		MockSource: mockSource,
+		Packages:   packages,
+	}
+}
+
+// Run evaluates each mutation and returns the combined report
+func (r *Runner) Run(mutations []Mutation) (*Report, error) {
+	source, err := os.ReadFile(r.MockSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock source: %w", err)
+	}
+	sourcePath, err := filepath.Abs(r.MockSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mock source: %w", err)
+	}
+
+	baseline, err := r.runTests("")
+	if err != nil {
+		return nil, fmt.Errorf("baseline test run failed: %w", err)
+	}
+	if baseline.buildFailed {
+		return nil, fmt.Errorf("baseline tests do not build:\n%s", baseline.output)
+	}
+
+	workDir, err := os.MkdirTemp("", "ccl-mutation-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	report := &Report{
+		Packages:       r.Packages,
+		BaselineFailed: sortedKeys(baseline.failed),
+	}
+
+	for _, m := range mutations {
+		start := time.Now()
+		result := Result{Mutation: m}
+
+		if count := strings.Count(string(source), m.Original); count != 1 {
+			result.Status = StatusNotApplied
+			result.Detail = fmt.Sprintf("original code found %d times", count)
+		} else {
+			overlay, err := writeOverlay(workDir, sourcePath, strings.Replace(string(source), m.Original, m.Replacement, 1))
+			if err != nil {
+				return nil, err
+			}
+
+			run, err := r.runTests(overlay)
+			if err != nil {
+				return nil, fmt.Errorf("test run for %s failed: %w", m.ID, err)
+			}
+
+			switch {
+			case run.buildFailed:
+				result.Status = StatusCompileError
+				result.Detail = run.output
+			default:
+				for test := range run.failed {
+					if !baseline.failed[test] {
+						result.KillingTests = append(result.KillingTests, test)
+					}
+				}
+				sort.Strings(result.KillingTests)
+				result.Status = StatusSurvived
+				if len(result.KillingTests) > 0 {
+					result.Status = StatusKilled
+				}
+			}
+		}
+
+		result.Duration = time.Since(start)
+		report.Results = append(report.Results, result)
+		if r.Progress != nil {
+			r.Progress(result)
+		}
+	}
+
+	return report, nil
+}
+
+// Survivors returns the mutants no test detected
+func (rep *Report) Survivors() []Result {
+	var survivors []Result
+	for _, result := range rep.Results {
+		if result.Status == StatusSurvived {
+			survivors = append(survivors, result)
+		}
+	}
+	return survivors
+}
+
+// Score returns the percentage of applied mutants that were killed
+func (rep *Report) Score() float64 {
+	killed, applied := 0, 0
+	for _, result := range rep.Results {
+		switch result.Status {
+		case StatusKilled:
+			killed++
+			applied++
+		case StatusSurvived:
+			applied++
+		}
+	}
+	if applied == 0 {
+		return 0
+	}
+	return float64(killed) / float64(applied) * 100
+}
+
+// testRun holds the failures observed in one go test invocation
+type testRun struct {
+	failed      map[string]bool
+	buildFailed bool
+	output      string
+}
+
+// testEvent is a single line of go test -json output
+type testEvent struct {
+	Action  string `json:"Action"`
+	Package string `json:"Package"`
+	Test    string `json:"Test"`
+	Output  string `json:"Output"`
+}
+
+// runTests runs the test packages, optionally with an overlay file, and collects failing tests
+func (r *Runner) runTests(overlay string) (*testRun, error) {
+	args := []string{"test", "-json", "-count=1"}
+	if overlay != "" {
+		args = append(args, "-overlay", overlay)
+	}
+	args = append(args, r.Packages...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	run := &testRun{failed: make(map[string]bool)}
+	var buildOutput strings.Builder
+	packageFailed := false
+
+	scanner := bufio.NewScanner(&stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		switch {
+		case event.Action == "fail" && event.Test != "":
+			run.failed[event.Package+"."+event.Test] = true
+		case event.Action == "fail":
+			packageFailed = true
+		case event.Action == "build-output":
+			buildOutput.WriteString(event.Output)
+		}
+	}
+
+	if runErr != nil {
+		if _, ok := runErr.(*exec.ExitError); !ok {
+			return nil, runErr
+		}
+		// A failing exit without any failing test means the packages did not build
+		if len(run.failed) == 0 && (packageFailed || stderr.Len() > 0) {
+			run.buildFailed = true
+			run.output = strings.TrimSpace(buildOutput.String() + stderr.String())
+		}
+	}
+
+	return run, nil
+}
+
+// writeOverlay writes the mutated source and a go build overlay file that substitutes it
+func writeOverlay(dir, sourcePath, mutated string) (string, error) {
+	mutatedPath := filepath.Join(dir, "ccl.go")
+	if err := os.WriteFile(mutatedPath, []byte(mutated), 0644); err != nil {
+		return "", fmt.Errorf("failed to write mutant: %w", err)
+	}
+
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {sourcePath: mutatedPath},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	overlayPath := filepath.Join(dir, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0644); err != nil {
+		return "", fmt.Errorf("failed to write overlay: %w", err)
+	}
+	return overlayPath, nil
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
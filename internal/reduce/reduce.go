@@ -0,0 +1,258 @@
+// Package reduce minimizes failing CCL inputs using delta debugging.
+//
+// A Reducer repeatedly tries smaller variants of a test's inputs and keeps any
+// variant for which the failure predicate still holds. Reduction runs in passes
+// until none of them makes progress:
+//   - lines: delta debugging (ddmin) over the input's lines
+//   - blocks: removing an entry together with its indented children, or just the children
+//   - tokens: shortening keys, values and continuation lines
+//
+// Multi-input tests are reduced one input at a time.
+package reduce
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNotFailing is returned by Reduce when the original inputs do not satisfy the
+// failure predicate, so there is no failure to preserve
+var ErrNotFailing = errors.New("original inputs do not satisfy the failure predicate")
+
+// Predicate reports whether the candidate inputs still reproduce the failure
+type Predicate func(inputs []string) (bool, error)
+
+// Reducer minimizes inputs against a failure predicate
+type Reducer struct {
+	failing  Predicate
+	seen     map[string]bool
+	Attempts int // Number of distinct candidates evaluated
+}
+
+// New creates a Reducer for the given failure predicate
+func New(failing Predicate) *Reducer {
+	return &Reducer{
+		failing: failing,
+		seen:    make(map[string]bool),
+	}
+}
+
+// Reduce returns the smallest inputs found that still satisfy the failure predicate.
+// It returns ErrNotFailing if the original inputs do not.
+func (r *Reducer) Reduce(inputs []string) ([]string, error) {
+	current := append([]string(nil), inputs...)
+	if failing, err := r.check(current); err != nil {
+		return nil, err
+	} else if !failing {
+		return nil, ErrNotFailing
+	}
+
+	for {
+		changed := false
+		for i := range current {
+			reduced, err := r.reduceInput(current, i)
+			if err != nil {
+				return nil, err
+			}
+			if reduced != current[i] {
+				current[i] = reduced
+				changed = true
+			}
+		}
+		if !changed {
+			return current, nil
+		}
+	}
+}
+
+// reduceInput minimizes inputs[index] while the other inputs stay fixed
+func (r *Reducer) reduceInput(inputs []string, index int) (string, error) {
+	test := func(lines []string) (bool, error) {
+		candidate := append([]string(nil), inputs...)
+		candidate[index] = strings.Join(lines, "\n")
+		return r.check(candidate)
+	}
+
+	lines := strings.Split(inputs[index], "\n")
+	for {
+		before := strings.Join(lines, "\n")
+
+		var err error
+		if lines, err = r.removeLines(lines, test); err != nil {
+			return "", err
+		}
+		if lines, err = r.removeBlocks(lines, test); err != nil {
+			return "", err
+		}
+		if lines, err = r.shortenTokens(lines, test); err != nil {
+			return "", err
+		}
+
+		if strings.Join(lines, "\n") == before {
+			return before, nil
+		}
+	}
+}
+
+// check evaluates the predicate, caching results for candidates already tried
+func (r *Reducer) check(inputs []string) (bool, error) {
+	key := strings.Join(inputs, "\x00")
+	if result, ok := r.seen[key]; ok {
+		return result, nil
+	}
+
+	r.Attempts++
+	result, err := r.failing(inputs)
+	if err != nil {
+		return false, err
+	}
+	r.seen[key] = result
+	return result, nil
+}
+
+// removeLines applies ddmin to the lines of an input, removing ever smaller chunks
+func (r *Reducer) removeLines(lines []string, test func([]string) (bool, error)) ([]string, error) {
+	granularity := 2
+	for len(lines) >= 2 {
+		chunk := (len(lines) + granularity - 1) / granularity
+		reduced := false
+
+		for start := 0; start < len(lines); start += chunk {
+			end := min(start+chunk, len(lines))
+			complement := append(append([]string(nil), lines[:start]...), lines[end:]...)
+
+			ok, err := test(complement)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				lines = complement
+				granularity = max(granularity-1, 2)
+				reduced = true
+				break
+			}
+		}
+
+		if !reduced {
+			if granularity >= len(lines) {
+				break
+			}
+			granularity = min(granularity*2, len(lines))
+		}
+	}
+	return lines, nil
+}
+
+// removeBlocks tries removing each entry with its indented children, then just the children
+func (r *Reducer) removeBlocks(lines []string, test func([]string) (bool, error)) ([]string, error) {
+	for i := 0; i < len(lines); i++ {
+		end := blockEnd(lines, i)
+		if end == i+1 {
+			continue
+		}
+
+		for _, start := range []int{i, i + 1} {
+			candidate := append(append([]string(nil), lines[:start]...), lines[end:]...)
+			ok, err := test(candidate)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				lines = candidate
+				break
+			}
+		}
+	}
+	return lines, nil
+}
+
+// shortenTokens tries shorter keys, values and continuation text on every line
+func (r *Reducer) shortenTokens(lines []string, test func([]string) (bool, error)) ([]string, error) {
+	for i := range lines {
+		for {
+			shortened := false
+			for _, line := range shorterLines(lines[i]) {
+				candidate := append([]string(nil), lines...)
+				candidate[i] = line
+
+				ok, err := test(candidate)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					lines = candidate
+					shortened = true
+					break
+				}
+			}
+			if !shortened {
+				break
+			}
+		}
+	}
+	return lines, nil
+}
+
+// blockEnd returns the index just past the indented block that starts at line i
+func blockEnd(lines []string, i int) int {
+	indent := indentation(lines[i])
+	end := i + 1
+	for end < len(lines) {
+		if strings.TrimSpace(lines[end]) != "" && indentation(lines[end]) <= indent {
+			break
+		}
+		end++
+	}
+	// Trailing blank lines belong to whatever follows the block
+	for end > i+1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return end
+}
+
+// shorterLines returns variants of line with a shortened key or value, shortest first
+func shorterLines(line string) []string {
+	var variants []string
+	if trimmed := strings.TrimRight(line, " \t"); trimmed != line {
+		variants = append(variants, trimmed)
+	}
+
+	key, value, hasEquals := strings.Cut(line, "=")
+	if !hasEquals {
+		return append(variants, replaceShorter(line)...)
+	}
+
+	for _, k := range replaceShorter(key) {
+		variants = append(variants, k+"="+value)
+	}
+	for _, v := range replaceShorter(value) {
+		variants = append(variants, key+"="+v)
+	}
+	return variants
+}
+
+// replaceShorter returns text with its trimmed content replaced by shorter prefixes,
+// keeping surrounding whitespace so indentation and line endings are preserved
+func replaceShorter(text string) []string {
+	content := strings.Trim(text, " \t\r")
+	if content == "" {
+		return nil
+	}
+	start := strings.Index(text, content)
+	prefix, suffix := text[:start], text[start+len(content):]
+
+	runes := []rune(content)
+	var candidates []string
+	for _, length := range []int{0, 1, len(runes) / 2} {
+		if length >= len(runes) {
+			continue
+		}
+		candidates = append(candidates, prefix+string(runes[:length])+suffix)
+	}
+	return candidates
+}
+
+// indentation counts the leading spaces and tabs of a line
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
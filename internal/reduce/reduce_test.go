@@ -0,0 +1,45 @@
+package reduce
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReduce_KeepsOnlyFailingBlock(t *testing.T) {
+	input := `title = Example
+database =
+  host = localhost
+  ports =
+    = 8000
+    = 8001
+user =
+  login = someone`
+
+	// Fails whenever an indented line is followed by an empty-keyed value starting with 8
+	reducer := New(func(inputs []string) (bool, error) {
+		return strings.Contains(inputs[0], "\n  ") && strings.Contains(inputs[0], "= 8"), nil
+	})
+
+	reduced, err := reducer.Reduce([]string{input})
+	if err != nil {
+		t.Fatalf("Reduce failed: %v", err)
+	}
+
+	want := []string{"    =\n    = 8"}
+	if !reflect.DeepEqual(reduced, want) {
+		t.Errorf("Expected %q, got %q", want, reduced)
+	}
+}
+
+func TestReduce_RejectsPassingInput(t *testing.T) {
+	reducer := New(func(inputs []string) (bool, error) { return false, nil })
+
+	if _, err := reducer.Reduce([]string{"a = 1\nb = 2"}); !errors.Is(err, ErrNotFailing) {
+		t.Errorf("Expected ErrNotFailing, got %v", err)
+	}
+	if reducer.Attempts != 1 {
+		t.Errorf("Expected only the original input to be checked, got %d attempts", reducer.Attempts)
+	}
+}
@@ -0,0 +1,339 @@
+// Package site renders the test corpus as a static HTML site, so that implementers
+// can browse tests without reading JSON.
+//
+// The site has an index page with the corpus totals, a behavior conflict matrix and
+// a list of source files, and one page per source file with an anchor per test.
+// Pages inline their styles and search script. The search index is written next to
+// them as a script file, so the site works from file:// and needs no external assets.
+package site
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/internal/stats"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Site is the corpus prepared for rendering
+type Site struct {
+	Version          string // Newest version declared by the source files
+	Pages            []Page
+	Conflicts        Matrix
+	TotalTests       int
+	TotalValidations int
+}
+
+// Page is one source file
+type Page struct {
+	Source  string // Path within the corpus, e.g. source_tests/core/api_comments.json
+	Name    string // File name without extension, e.g. api_comments
+	Tier    string
+	Version string
+	Tests   []Test
+}
+
+// Path is the page's file name in the site
+func (p Page) Path() string {
+	return p.Name + ".html"
+}
+
+// Test is a source test with its validations in file order
+type Test struct {
+	Name        string
+	Inputs      []string
+	Validations []Validation
+	Features    []string
+	Behaviors   []string
+	Variants    []string
+	Spec        []string
+	Conflicts   []string // kind:value, e.g. behavior:boolean_strict
+	AddedIn     string
+	ChangedIn   []string
+}
+
+// Validation is one expected function result
+type Validation struct {
+	Function string
+	Args     []string
+	Entries  []types.Entry // Expected entries of functions returning them
+	Text     string        // Any other expectation: text as-is, other values as indented JSON
+	Error    bool          // The function is expected to fail
+}
+
+// Matrix records which behaviors and variants are mutually exclusive
+type Matrix struct {
+	Tags  []string // Sorted, e.g. behavior:boolean_lenient
+	pairs map[string]bool
+}
+
+// Conflict reports whether two tags are mutually exclusive
+func (m Matrix) Conflict(a, b string) bool {
+	return a != b && m.pairs[a+"|"+b]
+}
+
+// Load reads the source tests under root/source_tests and the behavior conflicts
+// recorded in root/generated_tests
+func Load(root string) (*Site, error) {
+	files, err := loader.FindTestFiles(os.DirFS(root), "source_tests", loader.DiscoveryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no source tests found in %s/source_tests", root)
+	}
+
+	site := &Site{}
+	var newest *loader.Version
+	for _, file := range files {
+		page, err := loadPage(root, file)
+		if err != nil {
+			return nil, err
+		}
+		site.Pages = append(site.Pages, *page)
+		site.TotalTests += len(page.Tests)
+		for _, test := range page.Tests {
+			site.TotalValidations += len(test.Validations)
+		}
+
+		if page.Version == "" {
+			continue
+		}
+		version, err := loader.ParseVersion(page.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if newest == nil || version.Compare(*newest) > 0 {
+			newest = &version
+			site.Version = page.Version
+		}
+	}
+
+	generated := filepath.Join(root, "generated_tests")
+	if _, err := os.Stat(generated); os.IsNotExist(err) {
+		return site, nil
+	}
+	statistics, err := stats.NewEnhancedCollector(generated).CollectEnhancedStats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect conflict statistics: %w", err)
+	}
+	site.Conflicts = conflictMatrix(statistics.ConflictPairs)
+	return site, nil
+}
+
+// loadPage reads a source file. It is read directly rather than through the loader,
+// which keeps one validation per function, so that every validation is shown.
+func loadPage(root, file string) (*Page, error) {
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(file)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var source loader.CompactTestFile
+	if err := json.Unmarshal(data, &source); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	page := &Page{
+		Source:  file,
+		Name:    strings.TrimSuffix(filepath.Base(file), ".json"),
+		Tier:    string(loader.TierFromPath(file)),
+		Version: source.Version,
+	}
+	for _, compact := range source.Tests {
+		test := Test{
+			Name:      compact.Name,
+			Inputs:    compact.Inputs,
+			Features:  compact.Features,
+			Behaviors: compact.Behaviors,
+			Variants:  compact.Variants,
+			Spec:      compact.Spec,
+			Conflicts: compact.Conflicts.Tags(),
+			AddedIn:   compact.AddedIn,
+			ChangedIn: compact.ChangedIn,
+		}
+		for _, validation := range compact.Tests {
+			rendered, err := newValidation(validation)
+			if err != nil {
+				return nil, fmt.Errorf("%s: test %s: %w", file, compact.Name, err)
+			}
+			test.Validations = append(test.Validations, rendered)
+		}
+		page.Tests = append(page.Tests, test)
+	}
+	return page, nil
+}
+
+func newValidation(validation loader.CompactValidation) (Validation, error) {
+	rendered := Validation{Function: validation.Function, Args: validation.Args}
+	if validation.Expect == nil || validation.Error {
+		rendered.Error = true
+		return rendered, nil
+	}
+
+	if items, ok := validation.Expect.([]interface{}); ok && implementation.ProducesEntries(validation.Function) {
+		rendered.Entries = make([]types.Entry, 0, len(items))
+		for _, item := range items {
+			entry, _ := item.(map[string]interface{})
+			key, _ := entry["key"].(string)
+			value, _ := entry["value"].(string)
+			rendered.Entries = append(rendered.Entries, types.Entry{Key: key, Value: value})
+		}
+		return rendered, nil
+	}
+
+	if text, ok := validation.Expect.(string); ok {
+		rendered.Text = text
+		return rendered, nil
+	}
+	data, err := json.MarshalIndent(validation.Expect, "", "  ")
+	if err != nil {
+		return rendered, fmt.Errorf("failed to format %s expectation: %w", validation.Function, err)
+	}
+	rendered.Text = string(data)
+	return rendered, nil
+}
+
+// conflictMatrix turns the conflict pairs collected from flat tests, keyed by tag
+// with untagged names as values, into a symmetric matrix
+func conflictMatrix(conflictPairs map[string][]string) Matrix {
+	matrix := Matrix{pairs: make(map[string]bool)}
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		if !seen[tag] {
+			seen[tag] = true
+			matrix.Tags = append(matrix.Tags, tag)
+		}
+	}
+
+	for tag, conflicts := range conflictPairs {
+		category, _, found := strings.Cut(tag, ":")
+		if !found {
+			continue
+		}
+		for _, conflict := range conflicts {
+			other := category + ":" + conflict
+			if other == tag {
+				continue
+			}
+			add(tag)
+			add(other)
+			matrix.pairs[tag+"|"+other] = true
+			matrix.pairs[other+"|"+tag] = true
+		}
+	}
+	sort.Strings(matrix.Tags)
+	return matrix
+}
+
+// searchEntry is one test in the search index
+type searchEntry struct {
+	Name  string   `json:"n"`
+	Page  string   `json:"p"`
+	Tags  []string `json:"t"` // function:, feature:, behavior: and variant: tags
+	Input string   `json:"i"`
+}
+
+// maxIndexedInput is the length of input text included in the search index per test
+const maxIndexedInput = 200
+
+// searchIndex builds the script that defines the search index for every page
+func (s *Site) searchIndex() ([]byte, error) {
+	var entries []searchEntry
+	for _, page := range s.Pages {
+		for _, test := range page.Tests {
+			entry := searchEntry{Name: test.Name, Page: page.Path()}
+			for _, validation := range test.Validations {
+				entry.Tags = appendUnique(entry.Tags, "function:"+validation.Function)
+			}
+			for _, feature := range test.Features {
+				entry.Tags = append(entry.Tags, "feature:"+feature)
+			}
+			for _, behavior := range test.Behaviors {
+				entry.Tags = append(entry.Tags, "behavior:"+behavior)
+			}
+			for _, variant := range test.Variants {
+				entry.Tags = append(entry.Tags, "variant:"+variant)
+			}
+			input := []rune(strings.Join(test.Inputs, "\n"))
+			if len(input) > maxIndexedInput {
+				input = input[:maxIndexedInput]
+			}
+			entry.Input = string(input)
+			entries = append(entries, entry)
+		}
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build search index: %w", err)
+	}
+	var script bytes.Buffer
+	script.WriteString("window.CCL_TESTS = ")
+	script.Write(data)
+	script.WriteString(";\n")
+	return script.Bytes(), nil
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// Write renders the site into dir: index.html, one page per source file and the
+// search index. Existing files with the same names are replaced.
+func (s *Site) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	write := func(name string, data []byte) error {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		return nil
+	}
+
+	index, err := s.searchIndex()
+	if err != nil {
+		return err
+	}
+	if err := write(searchIndexFile, index); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, "index", s); err != nil {
+		return fmt.Errorf("failed to render index: %w", err)
+	}
+	if err := write("index.html", buf.Bytes()); err != nil {
+		return err
+	}
+
+	for _, page := range s.Pages {
+		buf.Reset()
+		if err := templates.ExecuteTemplate(&buf, "page", pageData{Site: s, Page: page}); err != nil {
+			return fmt.Errorf("failed to render %s: %w", page.Source, err)
+		}
+		if err := write(page.Path(), buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pageData is what a source file page is rendered from
+type pageData struct {
+	Site *Site
+	Page Page
+}
@@ -0,0 +1,106 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sourceFile = `{
+  "$schema": "../../schemas/source-format.json",
+  "version": "0.4.0",
+  "tests": [
+    {
+      "name": "boolean_yes",
+      "inputs": ["enabled = yes"],
+      "tests": [
+        {"function": "parse", "expect": [{"key": "enabled", "value": "yes"}]},
+        {"function": "get_bool", "args": ["enabled"], "expect": true},
+        {"function": "get_int", "args": ["enabled"], "expect": null}
+      ],
+      "behaviors": ["boolean_lenient"],
+      "features": ["comments"],
+      "added_in": "0.4.0"
+    }
+  ]
+}`
+
+const flatFile = `{
+  "$schema": "../schemas/generated-format.json",
+  "tests": [
+    {
+      "name": "boolean_yes_get_bool",
+      "inputs": ["enabled = yes"],
+      "validation": "get_bool",
+      "expected": {"count": 1, "value": true},
+      "args": ["enabled"],
+      "behaviors": ["boolean_lenient"],
+      "conflicts": {"behaviors": ["boolean_strict"]}
+    },
+    {
+      "name": "boolean_yes_strict_get_bool",
+      "inputs": ["enabled = yes"],
+      "validation": "get_bool",
+      "expected": {"count": 0},
+      "args": ["enabled"],
+      "behaviors": ["boolean_strict"],
+      "conflicts": {"behaviors": ["boolean_lenient"]}
+    }
+  ]
+}`
+
+func TestSite(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "source_tests", "core", "api_booleans.json"), sourceFile)
+	writeFile(t, filepath.Join(root, "generated_tests", "api_booleans.json"), flatFile)
+
+	site, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if site.Version != "0.4.0" || site.TotalTests != 1 || site.TotalValidations != 3 {
+		t.Errorf("Load() = version %q, %d tests, %d validations", site.Version, site.TotalTests, site.TotalValidations)
+	}
+	validations := site.Pages[0].Tests[0].Validations
+	if len(validations[0].Entries) != 1 || validations[1].Text != "true" || !validations[2].Error {
+		t.Errorf("Validations = %+v", validations)
+	}
+	if want := []string{"behavior:boolean_lenient", "behavior:boolean_strict"}; !reflect.DeepEqual(site.Conflicts.Tags, want) {
+		t.Errorf("Conflicts.Tags = %v, want %v", site.Conflicts.Tags, want)
+	}
+	if !site.Conflicts.Conflict("behavior:boolean_strict", "behavior:boolean_lenient") {
+		t.Error("boolean_strict does not conflict with boolean_lenient")
+	}
+
+	output := filepath.Join(root, "site")
+	if err := site.Write(output); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	for file, wants := range map[string][]string{
+		"index.html":        {`<a href="api_booleans.html">api_booleans</a>`, `title="behavior:boolean_lenient conflicts with behavior:boolean_strict"`},
+		"api_booleans.html": {`id="boolean_yes"`, `<code>enabled</code>`, `fails with an error`, `href="index.html?q=behavior%3Aboolean_lenient"`},
+		"search-index.js":   {`"n":"boolean_yes","p":"api_booleans.html"`, `"function:get_bool"`},
+	} {
+		data, err := os.ReadFile(filepath.Join(output, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
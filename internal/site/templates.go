@@ -0,0 +1,201 @@
+package site
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// searchIndexFile is the script every page loads the search index from
+const searchIndexFile = "search-index.js"
+
+var templates = template.Must(template.New("site").Funcs(template.FuncMap{
+	"search": func(tag string) string {
+		return "index.html?q=" + url.QueryEscape(tag)
+	},
+	"join": strings.Join,
+	"add":  func(a, b int) int { return a + b },
+}).Parse(layoutTemplate + indexTemplate + pageTemplate))
+
+// layoutTemplate holds the parts shared by every page: the head with the inlined
+// styles, the search box and the search script
+const layoutTemplate = `
+{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #1f2328; background: #fff; }
+header { background: #24292f; color: #fff; padding: 0.75rem 1.5rem; display: flex; gap: 1.5rem; align-items: center; flex-wrap: wrap; }
+header a { color: #fff; font-weight: 600; text-decoration: none; }
+main { max-width: 72rem; margin: 0 auto; padding: 1rem 1.5rem 3rem; }
+a { color: #0969da; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.3rem; }
+pre, code { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.85rem; }
+pre { background: #f6f8fa; border: 1px solid #d0d7de; border-radius: 6px; padding: 0.5rem 0.75rem; white-space: pre-wrap; margin: 0.25rem 0; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #d0d7de; padding: 0.2rem 0.5rem; text-align: left; vertical-align: top; }
+td code { white-space: pre-wrap; background: #f6f8fa; }
+.search { position: relative; flex: 1; max-width: 32rem; }
+.search input { width: 100%; box-sizing: border-box; padding: 0.35rem 0.6rem; border-radius: 6px; border: 1px solid #57606a; }
+.results { list-style: none; margin: 0.5rem 0 0; padding: 0; }
+header .results { position: absolute; z-index: 10; background: #fff; color: #1f2328; width: 100%; max-height: 60vh; overflow-y: auto; box-shadow: 0 4px 12px rgba(0,0,0,0.2); border-radius: 6px; }
+.results li { padding: 0.3rem 0.6rem; border-bottom: 1px solid #eaeef2; }
+.results .tags { color: #57606a; font-size: 0.8rem; }
+.chip { display: inline-block; border-radius: 1em; padding: 0.05rem 0.55rem; margin: 0.1rem; font-size: 0.8rem; text-decoration: none; border: 1px solid transparent; }
+.chip.feature { background: #ddf4ff; color: #0550ae; }
+.chip.behavior { background: #fbefff; color: #8250df; }
+.chip.variant { background: #fff8c5; color: #7d4e00; }
+.chip.spec { background: #eaeef2; color: #424a53; }
+.chip.conflict { background: #ffebe9; color: #cf222e; }
+.chip.version { background: #dafbe1; color: #116329; }
+.test { border: 1px solid #d0d7de; border-radius: 6px; padding: 0.5rem 1rem 1rem; margin: 1rem 0; }
+.test h3 { margin: 0.5rem 0; }
+.test h3 a.anchor { color: #8c959f; text-decoration: none; margin-left: 0.3rem; }
+.test:target { border-color: #0969da; box-shadow: 0 0 0 2px #b6e3ff; }
+.validation { margin: 0.75rem 0; }
+.function { font-weight: 600; }
+.error { color: #cf222e; }
+.empty { color: #8c959f; font-style: italic; }
+.matrix { overflow-x: auto; }
+.matrix th.col { writing-mode: vertical-rl; transform: rotate(180deg); white-space: nowrap; font-weight: normal; }
+.matrix td.conflict { background: #ffebe9; color: #cf222e; text-align: center; }
+.matrix tr:hover td, .matrix tr:hover th { outline: 1px solid #d0d7de; }
+.muted { color: #57606a; }
+</style>
+<script src="` + searchIndexFile + `"></script>
+</head>
+<body>
+<header>
+<a href="index.html">CCL test corpus</a>
+<div class="search">
+<input id="search" type="search" placeholder="Search tests, e.g. behavior:crlf_normalize_to_lf function:parse" autocomplete="off">
+<ul id="results" class="results" hidden></ul>
+</div>
+</header>
+<main>
+{{end}}
+
+{{define "chips"}}{{range .Features}}<a class="chip feature" href="{{search (printf "feature:%s" .)}}">{{.}}</a>{{end -}}
+{{range .Behaviors}}<a class="chip behavior" href="{{search (printf "behavior:%s" .)}}">{{.}}</a>{{end -}}
+{{range .Variants}}<a class="chip variant" href="{{search (printf "variant:%s" .)}}">{{.}}</a>{{end -}}
+{{range .Spec}}<span class="chip spec">§ {{.}}</span>{{end -}}
+{{range .Conflicts}}<a class="chip conflict" href="{{search .}}" title="Conflicts with {{.}}">✕ {{.}}</a>{{end -}}
+{{if .AddedIn}}<span class="chip version">added in {{.AddedIn}}</span>{{end -}}
+{{if .ChangedIn}}<span class="chip version">changed in {{join .ChangedIn ", "}}</span>{{end}}{{end}}
+
+{{define "foot"}}</main>
+<script>
+(function () {
+  var tests = window.CCL_TESTS || [];
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  var inline = document.getElementById("inline-results");
+  if (inline) { results = inline; }
+
+  function matches(test, term) {
+    if (test.n.toLowerCase().indexOf(term) >= 0) { return true; }
+    for (var i = 0; i < test.t.length; i++) {
+      var tag = test.t[i].toLowerCase();
+      if (tag.indexOf(term) >= 0) { return true; }
+    }
+    return test.i.toLowerCase().indexOf(term) >= 0;
+  }
+
+  function search(query) {
+    var terms = query.toLowerCase().split(/\s+/).filter(function (t) { return t; });
+    results.innerHTML = "";
+    if (terms.length === 0) { results.hidden = true; return; }
+    var found = tests.filter(function (test) {
+      return terms.every(function (term) { return matches(test, term); });
+    });
+    var summary = document.createElement("li");
+    summary.className = "muted";
+    summary.textContent = found.length + " matching test" + (found.length === 1 ? "" : "s") + (found.length > 100 ? ", showing the first 100" : "");
+    results.appendChild(summary);
+    found.slice(0, 100).forEach(function (test) {
+      var item = document.createElement("li");
+      var link = document.createElement("a");
+      link.href = test.p + "#" + encodeURIComponent(test.n);
+      link.textContent = test.n;
+      var tags = document.createElement("div");
+      tags.className = "tags";
+      tags.textContent = test.p.replace(/\.html$/, "") + " · " + test.t.join(" ");
+      item.appendChild(link);
+      item.appendChild(tags);
+      results.appendChild(item);
+    });
+    results.hidden = false;
+  }
+
+  input.addEventListener("input", function () { search(input.value); });
+  input.addEventListener("keydown", function (event) {
+    if (event.key === "Escape") { input.value = ""; search(""); }
+  });
+  var query = new URLSearchParams(window.location.search).get("q");
+  if (query) { input.value = query; search(query); }
+})();
+</script>
+</body>
+</html>
+{{end}}
+`
+
+// indexTemplate renders index.html from a Site
+const indexTemplate = `
+{{define "index"}}{{template "head" "CCL test corpus"}}
+<h1>CCL test corpus{{if .Version}} <span class="chip version">{{.Version}}</span>{{end}}</h1>
+<p>{{.TotalTests}} tests with {{.TotalValidations}} validations in {{len .Pages}} source files.
+Search by test name, input text or tag, e.g. <code>function:get_int</code>, <code>behavior:boolean_strict</code> or <code>feature:comments</code>.</p>
+<ul id="inline-results" class="results" hidden></ul>
+
+<h2 id="files">Source files</h2>
+<table>
+<tr><th>File</th><th>Tier</th><th>Version</th><th>Tests</th></tr>
+{{range .Pages}}<tr><td><a href="{{.Path}}">{{.Name}}</a></td><td>{{.Tier}}</td><td>{{.Version}}</td><td>{{len .Tests}}</td></tr>
+{{end}}</table>
+
+<h2 id="conflicts">Behavior conflicts</h2>
+{{if .Conflicts.Tags}}<p>Implementations choose one behavior or variant of each marked pair. Tests requiring either one are skipped by implementations that chose the other.</p>
+<div class="matrix">
+<table>
+<tr><th></th>{{range .Conflicts.Tags}}<th class="col" title="{{.}}">{{.}}</th>{{end}}</tr>
+{{$matrix := .Conflicts}}{{range $row := .Conflicts.Tags}}<tr><th><a href="{{search $row}}">{{$row}}</a></th>{{range $col := $matrix.Tags}}{{if $matrix.Conflict $row $col}}<td class="conflict" title="{{$row}} conflicts with {{$col}}">✕</td>{{else}}<td></td>{{end}}{{end}}</tr>
+{{end}}</table>
+</div>
+{{else}}<p class="empty">No conflicts found; run 'just generate' to create the flat tests they are collected from.</p>
+{{end}}{{template "foot"}}{{end}}
+`
+
+// pageTemplate renders one source file from a pageData
+const pageTemplate = `
+{{define "page"}}{{template "head" .Page.Name}}{{with .Page}}
+<h1>{{.Name}}</h1>
+<p class="muted">{{.Source}} · {{.Tier}} · version {{.Version}} · {{len .Tests}} tests</p>
+<details>
+<summary>Tests</summary>
+<ol>{{range .Tests}}<li><a href="#{{.Name}}">{{.Name}}</a></li>{{end}}</ol>
+</details>
+{{range .Tests}}
+<section class="test" id="{{.Name}}">
+<h3>{{.Name}}<a class="anchor" href="#{{.Name}}">#</a></h3>
+<div>{{template "chips" .}}</div>
+{{$count := len .Inputs}}{{range $i, $input := .Inputs}}
+<div class="muted">Input{{if gt $count 1}} {{add $i 1}} of {{$count}}{{end}}</div>
+<pre>{{$input}}</pre>
+{{end}}
+{{range .Validations}}<div class="validation">
+<div><span class="function">{{.Function}}</span>{{if .Args}} <code>({{join .Args ", "}})</code>{{end}}</div>
+{{if .Error}}<div class="error">✕ fails with an error</div>
+{{else if .Entries}}<table>
+<tr><th>Key</th><th>Value</th></tr>
+{{range .Entries}}<tr><td>{{if .Key}}<code>{{.Key}}</code>{{else}}<span class="empty">(empty)</span>{{end}}</td><td>{{if .Value}}<code>{{.Value}}</code>{{else}}<span class="empty">(empty)</span>{{end}}</td></tr>
+{{end}}</table>
+{{else if eq .Text "" "[]"}}<div class="empty">(empty)</div>
+{{else}}<pre>{{.Text}}</pre>
+{{end}}</div>
+{{end}}</section>
+{{end}}{{end}}{{template "foot"}}{{end}}
+`
@@ -0,0 +1,117 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// object is a JSON object that remembers its key order, so source files can be
+// rewritten without reordering fields that were not touched
+type object struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes a JSON object while recording key order
+func (o *object) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected JSON object, got %v", tok)
+	}
+
+	o.keys = nil
+	o.values = make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("failed to decode %q: %w", key, err)
+		}
+		if _, exists := o.values[key]; !exists {
+			o.keys = append(o.keys, key)
+		}
+		o.values[key] = value
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// MarshalJSON encodes the object with its original key order
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(o.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// has reports whether key is present
+func (o *object) has(key string) bool {
+	_, exists := o.values[key]
+	return exists
+}
+
+// get decodes the value of key into v. Missing keys leave v untouched.
+func (o *object) get(key string, v interface{}) error {
+	raw, exists := o.values[key]
+	if !exists {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// set replaces the value of key, inserting it after the key named after when it is new
+func (o *object) set(key string, value interface{}, after string) error {
+	raw, err := marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if !o.has(key) {
+		position := len(o.keys)
+		for i, existing := range o.keys {
+			if existing == after {
+				position = i + 1
+				break
+			}
+		}
+		o.keys = append(o.keys, "")
+		copy(o.keys[position+1:], o.keys[position:])
+		o.keys[position] = key
+	}
+	o.values[key] = raw
+	return nil
+}
+
+// marshal encodes v without escaping HTML characters, matching the source file style
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
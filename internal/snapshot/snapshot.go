@@ -0,0 +1,339 @@
+// Package snapshot fills in source test expectations by running an implementation.
+//
+// Validations whose "expect" field is missing or set to a pending placeholder are
+// evaluated and recorded. Existing expectations can also be recomputed so
+// that differences between the corpus and an implementation can be reviewed and
+// accepted. Files are rewritten with the same key order and indentation as the
+// hand-maintained source files, so only the changed expectations show up in diffs.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+)
+
+// PendingKey is the key of the placeholder {"$pending": true} marking an expectation
+// to be filled in. No CCL function returns an object with a boolean value, so the
+// placeholder cannot be a real expectation of any function.
+const PendingKey = "$pending"
+
+// PendingString is the string placeholder for an expectation to be filled in. It is
+// only a placeholder for functions that never return a string; for the others it
+// could be a real expectation.
+const PendingString = "pending"
+
+// stringFunctions are the functions whose result can be a string
+var stringFunctions = map[string]bool{
+	"get_string":       true,
+	"print":            true,
+	"canonical_format": true,
+	"pretty_print":     true,
+}
+
+// IsPending reports whether an expect value of function is a pending placeholder
+func IsPending(function string, expect interface{}) bool {
+	if expect == PendingString {
+		return !stringFunctions[function]
+	}
+	marker, ok := expect.(map[string]interface{})
+	return ok && len(marker) == 1 && marker[PendingKey] == true
+}
+
+// File is a source test file loaded for snapshotting
+type File struct {
+	Path  string
+	root  *object
+	tests []*sourceTest
+}
+
+// sourceTest is a single test within a source file
+type sourceTest struct {
+	obj         *object
+	name        string
+	inputs      []string
+	validations []*object
+}
+
+// Change describes an expectation that differs from the implementation's result
+type Change struct {
+	Test     string
+	Function string
+	Args     []string
+	Old      interface{} // Previous expectation; nil when it was missing or null
+	New      interface{} // Expectation produced by the implementation
+	Error    string      // Error reported by the implementation, recorded as null
+	Pending  bool        // True when the expectation was missing or the pending placeholder
+
+	validation *object
+}
+
+// Plan lists the changes an implementation would make to a file
+type Plan struct {
+	Changes     []*Change
+	Unsupported []string // test/function pairs the implementation could not evaluate
+}
+
+// LoadFile reads a source test file, keeping its field order
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	file := &File{Path: path, root: &object{}}
+	if err := json.Unmarshal(data, file.root); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var testObjects []*object
+	if err := file.root.get("tests", &testObjects); err != nil {
+		return nil, fmt.Errorf("failed to parse tests in %s: %w", path, err)
+	}
+
+	for i, obj := range testObjects {
+		test := &sourceTest{obj: obj}
+		if err := obj.get("name", &test.name); err != nil {
+			return nil, fmt.Errorf("test %d in %s: invalid name: %w", i, path, err)
+		}
+		if err := obj.get("inputs", &test.inputs); err != nil {
+			return nil, fmt.Errorf("test %s in %s: invalid inputs: %w", test.name, path, err)
+		}
+		if err := obj.get("tests", &test.validations); err != nil {
+			return nil, fmt.Errorf("test %s in %s: invalid validations: %w", test.name, path, err)
+		}
+		file.tests = append(file.tests, test)
+	}
+
+	return file, nil
+}
+
+// NewFile creates an empty source test file. Nothing is written until Write is called.
+func NewFile(path, schema, version string) (*File, error) {
+	file := &File{Path: path, root: &object{values: make(map[string]json.RawMessage)}}
+	if err := file.root.set("$schema", schema, ""); err != nil {
+		return nil, err
+	}
+	if err := file.root.set("version", version, "$schema"); err != nil {
+		return nil, err
+	}
+	if err := file.root.set("tests", []interface{}{}, "version"); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Version returns the corpus version declared by the file, or "" if it declares none
+func (f *File) Version() (string, error) {
+	var version string
+	if err := f.root.get("version", &version); err != nil {
+		return "", fmt.Errorf("invalid version in %s: %w", f.Path, err)
+	}
+	return version, nil
+}
+
+// SetVersion sets the corpus version declared by the file, adding it after $schema
+// if the file declares none
+func (f *File) SetVersion(version string) error {
+	return f.root.set("version", version, "$schema")
+}
+
+// AddTest appends a source test, such as a loader.CompactTest, to the file.
+// Test names must be unique within the file.
+func (f *File) AddTest(test interface{}) error {
+	raw, err := marshal(test)
+	if err != nil {
+		return fmt.Errorf("failed to encode test: %w", err)
+	}
+	obj := &object{}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return fmt.Errorf("test is not a JSON object: %w", err)
+	}
+
+	added := &sourceTest{obj: obj}
+	if err := obj.get("name", &added.name); err != nil || added.name == "" {
+		return fmt.Errorf("test has no name")
+	}
+	for _, existing := range f.tests {
+		if existing.name == added.name {
+			return fmt.Errorf("test %s already exists in %s", added.name, f.Path)
+		}
+	}
+	if err := obj.get("inputs", &added.inputs); err != nil {
+		return fmt.Errorf("test %s: invalid inputs: %w", added.name, err)
+	}
+	if err := obj.get("tests", &added.validations); err != nil {
+		return fmt.Errorf("test %s: invalid validations: %w", added.name, err)
+	}
+
+	f.tests = append(f.tests, added)
+	return nil
+}
+
+// Plan runs every validation in the file through impl and reports the expectations
+// that are pending or differ from the result. Nothing is modified until a change is applied.
+func (f *File) Plan(impl implementation.Implementation) (*Plan, error) {
+	plan := &Plan{}
+
+	for _, test := range f.tests {
+		for _, validation := range test.validations {
+			var function string
+			var args []string
+			var old interface{}
+			if err := validation.get("function", &function); err != nil {
+				return nil, fmt.Errorf("test %s: invalid function: %w", test.name, err)
+			}
+			if err := validation.get("args", &args); err != nil {
+				return nil, fmt.Errorf("test %s: invalid args for %s: %w", test.name, function, err)
+			}
+			if err := validation.get("expect", &old); err != nil {
+				return nil, fmt.Errorf("test %s: invalid expect for %s: %w", test.name, function, err)
+			}
+			pending := !validation.has("expect") || IsPending(function, old)
+
+			result, err := impl.Run(function, test.inputs, args)
+			if errors.Is(err, implementation.ErrUnsupported) {
+				plan.Unsupported = append(plan.Unsupported, test.name+"/"+function)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("test %s: %s: %w", test.name, function, err)
+			}
+
+			expect, err := implementation.Normalize(result.Expect())
+			if err != nil {
+				return nil, fmt.Errorf("test %s: %s: %w", test.name, function, err)
+			}
+			if !pending && reflect.DeepEqual(old, expect) {
+				continue
+			}
+
+			if pending {
+				old = nil
+			}
+			plan.Changes = append(plan.Changes, &Change{
+				Test:       test.name,
+				Function:   function,
+				Args:       args,
+				Old:        old,
+				New:        expect,
+				Error:      result.Error,
+				Pending:    pending,
+				validation: validation,
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply records the change's new expectation in the loaded file
+func (c *Change) Apply() error {
+	return c.validation.set("expect", c.New, "function")
+}
+
+// Write saves the file using the source format's two-space indentation
+func (f *File) Write() error {
+	for _, test := range f.tests {
+		if err := test.obj.set("tests", test.validations, ""); err != nil {
+			return fmt.Errorf("failed to encode test %s: %w", test.name, err)
+		}
+	}
+	testObjects := make([]*object, len(f.tests))
+	for i, test := range f.tests {
+		testObjects[i] = test.obj
+	}
+	if err := f.root.set("tests", testObjects, ""); err != nil {
+		return fmt.Errorf("failed to encode tests: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(f.root); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", f.Path, err)
+	}
+
+	if err := os.WriteFile(f.Path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Path, err)
+	}
+	return nil
+}
+
+// Diff renders the change as a line diff of the indented old and new expectations
+func (c *Change) Diff() string {
+	oldLines := indentLines(c.Old)
+	if c.Pending {
+		oldLines = nil
+	}
+	newLines := indentLines(c.New)
+
+	var sb strings.Builder
+	for _, line := range diffLines(oldLines, newLines) {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// indentLines formats value as indented JSON split into lines
+func indentLines(value interface{}) []string {
+	data, err := marshal(value)
+	if err != nil {
+		return []string{fmt.Sprintf("%v", value)}
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return []string{string(data)}
+	}
+	return strings.Split(buf.String(), "\n")
+}
+
+// diffLines produces a minimal line diff using the longest common subsequence.
+// Lines are prefixed with "  " (unchanged), "- " (removed) or "+ " (added).
+func diffLines(a, b []string) []string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+ "+b[j])
+	}
+	return lines
+}
@@ -0,0 +1,238 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/internal/implementation"
+	"github.com/catconflang/ccl-test-data/loader"
+)
+
+const sourceFile = `{
+  "$schema": "../../schemas/source-format.json",
+  "tests": [
+    {
+      "name": "basic",
+      "tests": [
+        {
+          "function": "parse",
+          "expect": {
+            "$pending": true
+          }
+        },
+        {
+          "function": "get_string",
+          "args": [
+            "key"
+          ]
+        }
+      ],
+      "inputs": [
+        "key = <value>"
+      ]
+    }
+  ]
+}
+`
+
+const snapshotFile = `{
+  "$schema": "../../schemas/source-format.json",
+  "tests": [
+    {
+      "name": "basic",
+      "tests": [
+        {
+          "function": "parse",
+          "expect": [
+            {
+              "key": "key",
+              "value": "<value>"
+            }
+          ]
+        },
+        {
+          "function": "get_string",
+          "expect": "<value>",
+          "args": [
+            "key"
+          ]
+        }
+      ],
+      "inputs": [
+        "key = <value>"
+      ]
+    }
+  ]
+}
+`
+
+func TestSnapshot_FillsPendingAndPreservesLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_basic.json")
+	if err := os.WriteFile(path, []byte(sourceFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	plan, err := file.Plan(implementation.NewMock())
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(plan.Changes) != 2 {
+		t.Fatalf("Expected 2 pending changes, got %d", len(plan.Changes))
+	}
+	for _, change := range plan.Changes {
+		if !change.Pending {
+			t.Errorf("Expected %s change to be pending", change.Function)
+		}
+		if err := change.Apply(); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+	}
+	if err := file.Write(); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != snapshotFile {
+		t.Errorf("Unexpected snapshot output:\n%s", written)
+	}
+
+	// A second run finds nothing left to do
+	file, err = LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	plan, err = file.Plan(implementation.NewMock())
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(plan.Changes) != 0 {
+		t.Errorf("Expected no changes after snapshot, got %d", len(plan.Changes))
+	}
+}
+
+func TestFile_AddTest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_playground.json")
+	file, err := NewFile(path, "../../schemas/source-format.json", "0.3.1")
+	if err != nil {
+		t.Fatalf("NewFile failed: %v", err)
+	}
+
+	test := map[string]interface{}{
+		"name":   "basic",
+		"inputs": []string{"key = <value>"},
+		"tests":  []map[string]interface{}{{"function": "parse", "expect": map[string]interface{}{PendingKey: true}}},
+	}
+	if err := file.AddTest(test); err != nil {
+		t.Fatalf("AddTest failed: %v", err)
+	}
+	if err := file.AddTest(test); err == nil {
+		t.Error("Expected an error adding a test with a duplicate name")
+	}
+	if err := file.Write(); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// The new file can be loaded and snapshotted like any other
+	file, err = LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if version, err := file.Version(); err != nil || version != "0.3.1" {
+		t.Errorf("Version() = %q, %v, want 0.3.1", version, err)
+	}
+	plan, err := file.Plan(implementation.NewMock())
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Test != "basic" {
+		t.Errorf("Expected one pending change for basic, got %+v", plan.Changes)
+	}
+}
+
+func TestFile_AddTestExcludedFromPinnedLoader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source_tests", "api_playground.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewFile(path, "../schemas/source-format.json", "0.4.0")
+	if err != nil {
+		t.Fatalf("NewFile failed: %v", err)
+	}
+	existing := loader.CompactTest{Name: "existing", Inputs: []string{"a = 1"}, Tests: []loader.CompactValidation{{Function: "parse", Expect: []interface{}{map[string]interface{}{"key": "a", "value": "1"}}}}}
+	if err := file.AddTest(existing); err != nil {
+		t.Fatalf("AddTest failed: %v", err)
+	}
+
+	// A new test is added in the next minor version, as the playground saves it
+	current, err := loader.ParseVersion("0.4.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := current.NextMinor().String()
+	if next != "0.5.0" {
+		t.Fatalf("NextMinor() = %s, want 0.5.0", next)
+	}
+	added := loader.CompactTest{Name: "added", Inputs: []string{"b = 2"}, Tests: []loader.CompactValidation{{Function: "parse", Expect: []interface{}{map[string]interface{}{"key": "b", "value": "2"}}}}, AddedIn: next}
+	if err := file.SetVersion(next); err != nil {
+		t.Fatalf("SetVersion failed: %v", err)
+	}
+	if err := file.AddTest(added); err != nil {
+		t.Fatalf("AddTest failed: %v", err)
+	}
+	if err := file.Write(); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	for pin, want := range map[string][]string{"0.4.0": {"existing"}, "0.5.0": {"existing", "added"}} {
+		testLoader := loader.NewTestLoader(dir, config.ImplementationConfig{CorpusVersion: pin})
+		files, err := testLoader.LoadAllFiles(loader.LoadOptions{Format: loader.FormatCompact, FilterMode: loader.FilterAll})
+		if err != nil {
+			t.Fatalf("LoadAllFiles failed: %v", err)
+		}
+		var names []string
+		for _, loaded := range files {
+			for _, test := range loaded.Tests {
+				names = append(names, test.Name)
+			}
+		}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("pinned to %s, loaded %v, want %v", pin, names, want)
+		}
+	}
+}
+
+func TestIsPending(t *testing.T) {
+	tests := []struct {
+		function string
+		expect   interface{}
+		want     bool
+	}{
+		{"get_string", map[string]interface{}{PendingKey: true}, true},
+		{"parse", "pending", true},
+		{"build_hierarchy", "pending", true},
+		{"get_int", "pending", true},
+		// These functions could return "pending", so the string is a real expectation
+		{"get_string", "pending", false},
+		{"print", "pending", false},
+		{"canonical_format", "pending", false},
+		{"parse", nil, false},
+		{"parse", "Pending", false},
+		{"parse", map[string]interface{}{PendingKey: "true"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsPending(tt.function, tt.expect); got != tt.want {
+			t.Errorf("IsPending(%s, %#v) = %v, want %v", tt.function, tt.expect, got, tt.want)
+		}
+	}
+}
@@ -106,6 +106,7 @@ type SourceTest struct {
 	Features  []string               `json:"features,omitempty"`
 	Behaviors []string               `json:"behaviors,omitempty"`
 	Variants  []string               `json:"variants,omitempty"`
+	Spec      []string               `json:"spec,omitempty"`
 	Conflicts map[string][]string    `json:"conflicts,omitempty"`
 }
 
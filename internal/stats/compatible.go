@@ -0,0 +1,153 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/loader"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// ExclusionReasons lists the reasons a test can be excluded, in display order
+var ExclusionReasons = []loader.ExclusionReason{
+	loader.ReasonMissingFunction,
+	loader.ReasonMissingFeature,
+	loader.ReasonBehaviorConflict,
+	loader.ReasonVariantMismatch,
+	loader.ReasonTierNotIncluded,
+}
+
+// CompatibleStatistics restricts the test suite statistics to the tests compatible
+// with an implementation, and lists the tests left out with their exclusion reason
+type CompatibleStatistics struct {
+	Implementation  string                          `json:"implementation,omitempty"`
+	TotalTests      int                             `json:"totalTests"`
+	CompatibleTests int                             `json:"compatibleTests"`
+	ExcludedTests   int                             `json:"excludedTests"`
+	Functions       map[string]int                  `json:"functions"`
+	Features        map[string]int                  `json:"features"`
+	Excluded        map[string][]types.ExcludedTest `json:"excluded"`
+}
+
+// CollectCompatibleStats analyzes the flat tests in testDir against an implementation
+// configuration. Tests added after the configuration's pinned corpus version are left
+// out entirely, as the test loader does.
+func CollectCompatibleStats(testDir string, cfg config.ImplementationConfig) (*CompatibleStatistics, error) {
+	fsys := os.DirFS(testDir)
+	files, err := loader.FindTestFiles(fsys, ".", loader.DiscoveryOptions{Exclude: []string{"*schema.json"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find test files: %w", err)
+	}
+
+	var pin *loader.Version
+	if cfg.CorpusVersion != "" {
+		version, err := loader.ParseVersion(cfg.CorpusVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid corpus version: %w", err)
+		}
+		pin = &version
+	}
+
+	testLoader := loader.NewTestLoaderFS(fsys, cfg)
+	var tests []types.TestCase
+	for _, file := range files {
+		suite, err := testLoader.LoadTestFile(file, loader.LoadOptions{Format: loader.FormatFlat})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		for _, test := range suite.Tests {
+			if pin != nil {
+				added, err := loader.AddedAfter(test, *pin)
+				if err != nil {
+					return nil, err
+				}
+				if added {
+					continue
+				}
+			}
+			tests = append(tests, test)
+		}
+	}
+
+	statistics := testLoader.GetTestStatistics(tests)
+	return &CompatibleStatistics{
+		Implementation:  cfg.Name,
+		TotalTests:      statistics.TotalTests,
+		CompatibleTests: statistics.CompatibleTests,
+		ExcludedTests:   statistics.TotalTests - statistics.CompatibleTests,
+		Functions:       statistics.CompatibleByFunction,
+		Features:        statistics.CompatibleByFeature,
+		Excluded:        statistics.Excluded,
+	}, nil
+}
+
+// PrintCompatibleStats prints implementation-filtered statistics in a human-readable
+// format. With verbose, every excluded test is listed rather than the first few per reason.
+func PrintCompatibleStats(stats *CompatibleStatistics, verbose bool) {
+	const listed = 5
+
+	fmt.Printf("📊 Compatible CCL Test Suite Statistics\n\n")
+
+	fmt.Printf("🔍 Overview:\n")
+	fmt.Printf("  Total Tests: %d\n", stats.TotalTests)
+	fmt.Printf("  Compatible Tests: %d\n", stats.CompatibleTests)
+	fmt.Printf("  Excluded Tests: %d\n\n", stats.ExcludedTests)
+
+	fmt.Printf("⚙️  Function Requirements:\n")
+	for _, fn := range sortedKeys(stats.Functions) {
+		fmt.Printf("  function:%s: %d tests\n", fn, stats.Functions[fn])
+	}
+	fmt.Println()
+
+	if len(stats.Features) > 0 {
+		fmt.Printf("🎨 Language Features:\n")
+		for _, feature := range sortedKeys(stats.Features) {
+			fmt.Printf("  feature:%s: %d tests\n", feature, stats.Features[feature])
+		}
+		fmt.Println()
+	}
+
+	if stats.ExcludedTests == 0 {
+		return
+	}
+	fmt.Printf("🚫 Excluded Tests:\n")
+	for _, reason := range ExclusionReasons {
+		excluded := stats.Excluded[string(reason)]
+		if len(excluded) == 0 {
+			continue
+		}
+
+		// Summarize by the function, feature, behavior, variant or tier at fault
+		byDetail := make(map[string]int)
+		for _, test := range excluded {
+			byDetail[test.Detail]++
+		}
+		fmt.Printf("  %s: %d tests\n", reason, len(excluded))
+		for _, detail := range sortedKeys(byDetail) {
+			fmt.Printf("    %s: %d\n", detail, byDetail[detail])
+		}
+
+		shown := excluded
+		if !verbose && len(shown) > listed {
+			shown = shown[:listed]
+		}
+		for _, test := range shown {
+			fmt.Printf("      - %s (%s)\n", test.Name, test.Detail)
+		}
+		if len(shown) < len(excluded) {
+			fmt.Printf("      ... and %d more\n", len(excluded)-len(shown))
+		}
+	}
+	fmt.Println()
+}
+
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
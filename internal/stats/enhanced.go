@@ -37,6 +37,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/catconflang/ccl-test-data/generator"
 	"github.com/catconflang/ccl-test-data/types"
 )
 
@@ -108,13 +109,17 @@ type EnhancedStatistics struct {
 	ConflictGroups    []ConflictGroup     `json:"conflictGroups"`
 	MutuallyExclusive int                 `json:"mutuallyExclusiveTests"`
 
+	// Specification coverage (source format only, requires spec sections)
+	SpecCoverage *SpecCoverage `json:"specCoverage,omitempty"`
+
 	// Legacy compatibility
 	Categories map[string]*CategoryStats `json:"categories"`
 }
 
 // EnhancedCollector provides detailed statistics about the new tagging system
 type EnhancedCollector struct {
-	testDir string
+	testDir      string
+	specSections []generator.SpecSection
 }
 
 // NewEnhancedCollector creates a new enhanced statistics collector
@@ -122,6 +127,12 @@ func NewEnhancedCollector(testDir string) *EnhancedCollector {
 	return &EnhancedCollector{testDir: testDir}
 }
 
+// WithSpecSections enables spec coverage reporting against the given specification sections
+func (c *EnhancedCollector) WithSpecSections(sections []generator.SpecSection) *EnhancedCollector {
+	c.specSections = sections
+	return c
+}
+
 // parseTag extracts category and name from structured tags like "function:parse"
 func parseTag(tag string) (category, name string) {
 	parts := strings.SplitN(tag, ":", 2)
@@ -233,6 +244,7 @@ func (c *EnhancedCollector) analyzeSourceTests(sourceTests []SourceTest, filePat
 			"features":   featuresCopy,
 			"behaviors":  behaviorsCopy,
 			"variants":   variantsCopy,
+			"spec":       append([]string{}, test.Spec...),
 		}
 
 		// Only include conflicts field if it has actual values
@@ -369,6 +381,8 @@ func (c *EnhancedCollector) CollectEnhancedStats() (*EnhancedStatistics, error)
 		"other":               {Files: make(map[string]*FileStats)},
 	}
 
+	spec := newSpecCoverageBuilder(c.specSections)
+
 	conflictPairs := make(map[string]map[string]int)
 	allConflicts := make(map[string]map[string]bool) // behavior -> set of conflicts
 
@@ -551,6 +565,11 @@ func (c *EnhancedCollector) CollectEnhancedStats() (*EnhancedStatistics, error)
 				}
 			}
 
+			// Spec section coverage (only source format tests carry spec references)
+			if sections, ok := testData["spec"].([]string); ok {
+				spec.add(sections, variants, assertions)
+			}
+
 			// Legacy category stats for compatibility
 			category := categorizeByFeature(feature)
 			if stats.Categories[category] == nil {
@@ -584,6 +603,8 @@ func (c *EnhancedCollector) CollectEnhancedStats() (*EnhancedStatistics, error)
 		stats.ConflictPairs[tag] = conflictList
 	}
 
+	stats.SpecCoverage = spec.build()
+
 	return stats, nil
 }
 
@@ -743,6 +764,11 @@ func PrintEnhancedStats(stats *EnhancedStatistics) {
 		fmt.Println()
 	}
 
+	// Specification coverage
+	if stats.SpecCoverage != nil {
+		printSpecCoverage(stats.SpecCoverage)
+	}
+
 	// Conflict relationships - display as bidirectional pairs
 	if len(stats.ConflictPairs) > 0 {
 		fmt.Printf("⚔️  Conflict Relationships (mutually exclusive pairs):\n")
@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/generator"
+)
+
+// SpecSectionStats describes how a single specification section is tested
+type SpecSectionStats struct {
+	Title      string   `json:"title"`
+	Tests      int      `json:"tests"`
+	Assertions int      `json:"assertions"`
+	Variants   []string `json:"variants"` // Variants the section's tests run under
+}
+
+// SpecCoverage maps specification sections to the source tests that reference them
+type SpecCoverage struct {
+	Sections      map[string]*SpecSectionStats `json:"sections"`
+	Untested      []string                     `json:"untested"`      // Sections no test references
+	ProposedOnly  []string                     `json:"proposedOnly"`  // Sections tested only by proposed_behavior tests
+	SingleVariant map[string]string            `json:"singleVariant"` // Other sections tested under one variant only
+	Unknown       []string                     `json:"unknown"`       // Referenced sections missing from the spec section list
+	order         []string
+}
+
+// specCoverageBuilder accumulates spec references while tests are collected
+type specCoverageBuilder struct {
+	sections []generator.SpecSection
+	stats    map[string]*SpecSectionStats
+	variants map[string]map[string]bool
+	tests    int // Source format tests seen, with or without spec references
+}
+
+// newSpecCoverageBuilder creates a builder for the given sections; nil sections disable spec coverage
+func newSpecCoverageBuilder(sections []generator.SpecSection) *specCoverageBuilder {
+	return &specCoverageBuilder{
+		sections: sections,
+		stats:    make(map[string]*SpecSectionStats),
+		variants: make(map[string]map[string]bool),
+	}
+}
+
+// add records a test referencing the given sections. Tests without variants run under every variant.
+func (b *specCoverageBuilder) add(sections, variants []string, assertions int) {
+	b.tests++
+	if len(variants) == 0 {
+		for _, variant := range config.AllVariants() {
+			variants = append(variants, string(variant))
+		}
+	}
+
+	for _, section := range sections {
+		if b.stats[section] == nil {
+			b.stats[section] = &SpecSectionStats{}
+			b.variants[section] = make(map[string]bool)
+		}
+		b.stats[section].Tests++
+		b.stats[section].Assertions += assertions
+		for _, variant := range variants {
+			b.variants[section][variant] = true
+		}
+	}
+}
+
+// build produces the coverage report, or nil when no spec sections are known
+// or no source format tests were collected
+func (b *specCoverageBuilder) build() *SpecCoverage {
+	if len(b.sections) == 0 || b.tests == 0 {
+		return nil
+	}
+
+	coverage := &SpecCoverage{
+		Sections:      make(map[string]*SpecSectionStats),
+		Untested:      []string{},
+		ProposedOnly:  []string{},
+		SingleVariant: make(map[string]string),
+		Unknown:       []string{},
+	}
+
+	known := make(map[string]bool)
+	for _, section := range b.sections {
+		known[section.ID] = true
+		coverage.order = append(coverage.order, section.ID)
+
+		sectionStats := b.stats[section.ID]
+		if sectionStats == nil {
+			sectionStats = &SpecSectionStats{}
+		}
+		sectionStats.Title = section.Title
+		sectionStats.Variants = sortedSet(b.variants[section.ID])
+		coverage.Sections[section.ID] = sectionStats
+
+		switch {
+		case sectionStats.Tests == 0:
+			coverage.Untested = append(coverage.Untested, section.ID)
+		case len(sectionStats.Variants) == 1 && sectionStats.Variants[0] == string(config.VariantProposed):
+			coverage.ProposedOnly = append(coverage.ProposedOnly, section.ID)
+		case len(sectionStats.Variants) == 1:
+			coverage.SingleVariant[section.ID] = sectionStats.Variants[0]
+		}
+	}
+
+	for section := range b.stats {
+		if !known[section] {
+			coverage.Unknown = append(coverage.Unknown, section)
+		}
+	}
+	sort.Strings(coverage.Unknown)
+
+	return coverage
+}
+
+// sortedSet returns the members of a set in sorted order, never nil
+func sortedSet(set map[string]bool) []string {
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// printSpecCoverage prints the specification coverage section of the enhanced stats
+func printSpecCoverage(coverage *SpecCoverage) {
+	fmt.Printf("📖 Spec Coverage:\n")
+	for _, id := range coverage.order {
+		section := coverage.Sections[id]
+		if section.Tests == 0 {
+			fmt.Printf("  %s (%s): no tests\n", id, section.Title)
+			continue
+		}
+		fmt.Printf("  %s (%s): %d tests (%d assertions) [%s]\n",
+			id, section.Title, section.Tests, section.Assertions, strings.Join(section.Variants, ", "))
+	}
+	fmt.Println()
+
+	if len(coverage.Untested) > 0 {
+		fmt.Printf("  ⚠️  Sections with no tests: %s\n", strings.Join(coverage.Untested, ", "))
+	}
+	if len(coverage.ProposedOnly) > 0 {
+		fmt.Printf("  ⚠️  Sections with only %s tests: %s\n", config.VariantProposed, strings.Join(coverage.ProposedOnly, ", "))
+	}
+	if len(coverage.SingleVariant) > 0 {
+		sections := make([]string, 0, len(coverage.SingleVariant))
+		for id, variant := range coverage.SingleVariant {
+			sections = append(sections, fmt.Sprintf("%s (%s)", id, variant))
+		}
+		sort.Strings(sections)
+		fmt.Printf("  ⚠️  Sections tested in one variant only: %s\n", strings.Join(sections, ", "))
+	}
+	if len(coverage.Unknown) > 0 {
+		fmt.Printf("  ❓ Unknown sections referenced by tests: %s\n", strings.Join(coverage.Unknown, ", "))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,47 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/catconflang/ccl-test-data/generator"
+)
+
+func TestSpecCoverage_ReportsGaps(t *testing.T) {
+	builder := newSpecCoverageBuilder([]generator.SpecSection{
+		{ID: "entries", Title: "Key-value entries"},
+		{ID: "comments", Title: "Comments"},
+		{ID: "tabs", Title: "Tabs"},
+		{ID: "lists", Title: "Lists"},
+	})
+
+	builder.add([]string{"entries"}, nil, 2)
+	builder.add([]string{"tabs"}, []string{"proposed_behavior"}, 1)
+	builder.add([]string{"lists"}, []string{"reference_compliant"}, 1)
+	builder.add([]string{"lists", "unknown-section"}, []string{"reference_compliant"}, 1)
+
+	coverage := builder.build()
+
+	if !reflect.DeepEqual(coverage.Untested, []string{"comments"}) {
+		t.Errorf("Expected untested [comments], got %v", coverage.Untested)
+	}
+	if !reflect.DeepEqual(coverage.ProposedOnly, []string{"tabs"}) {
+		t.Errorf("Expected proposed-only [tabs], got %v", coverage.ProposedOnly)
+	}
+	if !reflect.DeepEqual(coverage.SingleVariant, map[string]string{"lists": "reference_compliant"}) {
+		t.Errorf("Expected single-variant lists, got %v", coverage.SingleVariant)
+	}
+	if !reflect.DeepEqual(coverage.Unknown, []string{"unknown-section"}) {
+		t.Errorf("Expected unknown [unknown-section], got %v", coverage.Unknown)
+	}
+	if entries := coverage.Sections["entries"]; entries.Tests != 1 || len(entries.Variants) != 2 {
+		t.Errorf("Expected entries covered once in both variants, got %+v", entries)
+	}
+}
+
+func TestSpecCoverage_NilWithoutSourceTests(t *testing.T) {
+	builder := newSpecCoverageBuilder([]generator.SpecSection{{ID: "entries"}})
+	if coverage := builder.build(); coverage != nil {
+		t.Errorf("Expected no coverage without source tests, got %+v", coverage)
+	}
+}
@@ -0,0 +1,105 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/config"
+)
+
+// DiscoveryOptions selects test files when searching a test directory recursively.
+// Patterns use path.Match syntax. A pattern containing a slash matches the path relative
+// to the test directory, such as "experimental/*.json"; any other pattern matches the
+// file or directory name.
+type DiscoveryOptions struct {
+	Include []string // Files to load; defaults to "*.json"
+	Exclude []string // Files or directories to skip
+}
+
+// FindTestFiles returns the test files under dir in fsys, searching subdirectories in
+// lexical order. Paths are slash-separated and include dir. A missing dir has no tests.
+func FindTestFiles(fsys fs.FS, dir string, opts DiscoveryOptions) ([]string, error) {
+	include := opts.Include
+	if len(include) == 0 {
+		include = []string{"*.json"}
+	}
+
+	var files []string
+	err := fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == dir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+
+		rel := strings.TrimPrefix(p, dir+"/")
+		if dir == "." {
+			rel = p
+		}
+
+		excluded, err := matchAny(opts.Exclude, rel)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if excluded {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		included, err := matchAny(include, rel)
+		if err != nil {
+			return err
+		}
+		if included && !excluded {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+// TierFromPath returns the tier of a test file from the nearest enclosing directory
+// named after a tier, such as "core" in "source_tests/core/api_comments.json". Files
+// outside a tier directory have no tier.
+func TierFromPath(filename string) config.CCLTier {
+	dirs := strings.Split(path.Dir(strings.ReplaceAll(filename, "\\", "/")), "/")
+	for i := len(dirs) - 1; i >= 0; i-- {
+		for _, tier := range config.AllTiers() {
+			if dirs[i] == string(tier) {
+				return tier
+			}
+		}
+	}
+	return ""
+}
+
+// matchAny reports whether rel matches any of the patterns
+func matchAny(patterns []string, rel string) (bool, error) {
+	for _, pattern := range patterns {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,90 @@
+package loader
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/catconflang/ccl-test-data/config"
+)
+
+func TestFindTestFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"source_tests/core/api_comments.json":         {},
+		"source_tests/core/property_round_trip.json":  {},
+		"source_tests/core/README.md":                 {},
+		"source_tests/experimental/api_dotted.json":   {},
+		"source_tests/experimental/drafts/draft.json": {},
+		"generated_tests/api_comments.json":           {},
+	}
+
+	tests := []struct {
+		name     string
+		opts     DiscoveryOptions
+		expected []string
+	}{
+		{
+			name: "default",
+			expected: []string{
+				"source_tests/core/api_comments.json",
+				"source_tests/core/property_round_trip.json",
+				"source_tests/experimental/api_dotted.json",
+				"source_tests/experimental/drafts/draft.json",
+			},
+		},
+		{
+			name: "exclude directory",
+			opts: DiscoveryOptions{Exclude: []string{"experimental"}},
+			expected: []string{
+				"source_tests/core/api_comments.json",
+				"source_tests/core/property_round_trip.json",
+			},
+		},
+		{
+			name: "include relative path",
+			opts: DiscoveryOptions{Include: []string{"experimental/*.json"}},
+			expected: []string{
+				"source_tests/experimental/api_dotted.json",
+			},
+		},
+		{
+			name: "exclude file name",
+			opts: DiscoveryOptions{Include: []string{"api_*.json"}, Exclude: []string{"*_dotted.json"}},
+			expected: []string{
+				"source_tests/core/api_comments.json",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := FindTestFiles(fsys, "source_tests", tt.opts)
+			if err != nil {
+				t.Fatalf("FindTestFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(files, tt.expected) {
+				t.Errorf("FindTestFiles() = %v, want %v", files, tt.expected)
+			}
+		})
+	}
+
+	files, err := FindTestFiles(fsys, "missing", DiscoveryOptions{})
+	if err != nil || len(files) != 0 {
+		t.Errorf("FindTestFiles() on missing directory = %v, %v; want no files", files, err)
+	}
+}
+
+func TestTierFromPath(t *testing.T) {
+	tests := map[string]config.CCLTier{
+		"source_tests/core/api_comments.json":              config.TierCore,
+		"source_tests/experimental/drafts/draft.json":      config.TierExperimental,
+		"generated_tests/api_comments.json":                "",
+		`C:\ccl\source_tests\experimental\api_dotted.json`: config.TierExperimental,
+	}
+
+	for filename, expected := range tests {
+		if tier := TierFromPath(filename); tier != expected {
+			t.Errorf("TierFromPath(%q) = %q, want %q", filename, tier, expected)
+		}
+	}
+}
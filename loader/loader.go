@@ -5,6 +5,7 @@ package loader
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -15,6 +16,7 @@ import (
 // TestLoader handles both source and flat format loading with type-safe filtering
 type TestLoader struct {
 	TestDataPath string
+	FS           fs.FS // Test corpus to read from instead of TestDataPath on disk, if set
 	Config       config.ImplementationConfig
 	UseFlat      bool // true = generated flat format, false = source format
 }
@@ -24,13 +26,18 @@ type LoadOptions struct {
 	Format       TestFormat                // Source or Flat
 	FilterMode   FilterMode                // Compatible, All, or Custom
 	CustomFilter func(types.TestCase) bool // Custom filtering function
+	Discovery    DiscoveryOptions          // Include and exclude patterns for test files
+
+	// Corpus version to load, leaving out tests added after it. Defaults to the
+	// implementation's pinned CorpusVersion; empty loads every test.
+	TargetVersion string
 }
 
 // TestFormat specifies which test format to load
 type TestFormat int
 
 const (
-	FormatCompact TestFormat = iota // source_tests/*.json (compact arrays)
+	FormatCompact TestFormat = iota // source_tests/**/*.json (compact arrays)
 	FormatFlat                      // generated_tests/ (implementation-friendly)
 )
 
@@ -52,42 +59,101 @@ func NewTestLoader(testDataPath string, cfg config.ImplementationConfig) *TestLo
 	}
 }
 
-// LoadAllTests loads all tests from the configured test data path
+// NewTestLoaderFS creates a test loader that reads source_tests and generated_tests
+// from a file system, such as the corpus embedded in the ccl_test_data package
+func NewTestLoaderFS(fsys fs.FS, cfg config.ImplementationConfig) *TestLoader {
+	return &TestLoader{
+		FS:      fsys,
+		Config:  cfg,
+		UseFlat: true, // Default to flat format
+	}
+}
+
+// FileTests is the tests loaded from a single corpus file
+type FileTests struct {
+	File  string // Path the tests were loaded from, as passed to LoadTestFile
+	Tests []types.TestCase
+}
+
+// LoadAllTests loads all tests from the configured test data path, searching the
+// test directory and its subdirectories
 func (tl *TestLoader) LoadAllTests(opts LoadOptions) ([]types.TestCase, error) {
+	files, err := tl.LoadAllFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var allTests []types.TestCase
+	for _, file := range files {
+		allTests = append(allTests, file.Tests...)
+	}
+	return allTests, nil
+}
+
+// LoadAllFiles loads the same tests as LoadAllTests, grouped by the file they came from
+func (tl *TestLoader) LoadAllFiles(opts LoadOptions) ([]FileTests, error) {
 	var testDir string
-	var pattern string
 
 	switch opts.Format {
 	case FormatCompact:
-		testDir = filepath.Join(tl.TestDataPath, "source_tests")
-		pattern = "*.json"
+		testDir = "source_tests"
 	case FormatFlat:
-		testDir = filepath.Join(tl.TestDataPath, "generated_tests")
-		pattern = "*.json"
+		testDir = "generated_tests"
 	default:
 		return nil, fmt.Errorf("unsupported test format: %v", opts.Format)
 	}
 
-	files, err := filepath.Glob(filepath.Join(testDir, pattern))
+	fsys := tl.FS
+	if fsys == nil {
+		root := tl.TestDataPath
+		if root == "" {
+			root = "."
+		}
+		fsys = os.DirFS(root)
+	}
+	files, err := FindTestFiles(fsys, testDir, opts.Discovery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find test files: %w", err)
 	}
+	if tl.FS == nil {
+		for i, file := range files {
+			files[i] = filepath.Join(tl.TestDataPath, filepath.FromSlash(file))
+		}
+	}
 
-	var allTests []types.TestCase
+	target := opts.TargetVersion
+	if target == "" {
+		target = tl.Config.CorpusVersion
+	}
+
+	var loaded []FileTests
 	for _, file := range files {
 		suite, err := tl.LoadTestFile(file, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load %s: %w", file, err)
 		}
-		allTests = append(allTests, suite.Tests...)
+		tests := suite.Tests
+		if target != "" {
+			if tests, err = filterByVersion(tests, target); err != nil {
+				return nil, err
+			}
+		}
+		loaded = append(loaded, FileTests{File: file, Tests: tl.applyFiltering(tests, opts)})
 	}
 
-	return tl.applyFiltering(allTests, opts), nil
+	return loaded, nil
 }
 
-// LoadTestFile loads a single test file
+// LoadTestFile loads a single test file. With an FS, filename is a slash-separated
+// path within it, such as "generated_tests/api_comments.json".
 func (tl *TestLoader) LoadTestFile(filename string, opts LoadOptions) (*types.TestSuite, error) {
-	data, err := os.ReadFile(filename)
+	var data []byte
+	var err error
+	if tl.FS != nil {
+		data, err = fs.ReadFile(tl.FS, filename)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -117,22 +183,30 @@ func (tl *TestLoader) LoadTestFile(filename string, opts LoadOptions) (*types.Te
 
 		suite = types.TestSuite{
 			Suite:   "Flat Format",
-			Version: "1.0",
+			Version: testSuite.Version,
 			Tests:   tests,
 		}
 	} else {
 		// Compact format - array of compact test objects
-		tests, err := tl.loadCompactFormat(data)
+		tests, version, err := tl.loadCompactFormat(data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse compact format: %w", err)
 		}
 		suite = types.TestSuite{
 			Suite:   "Compact Format",
-			Version: "1.0",
+			Version: version,
 			Tests:   tests,
 		}
 	}
 
+	// Record the tier of source tests from their directory
+	tier := string(TierFromPath(filename))
+	for i := range suite.Tests {
+		if suite.Tests[i].Tier == "" {
+			suite.Tests[i].Tier = tier
+		}
+	}
+
 	return &suite, nil
 }
 
@@ -186,6 +260,26 @@ func (tl *TestLoader) applyFiltering(tests []types.TestCase, opts LoadOptions) [
 	}
 }
 
+// filterByVersion leaves out tests added after the target corpus version
+func filterByVersion(tests []types.TestCase, target string) ([]types.TestCase, error) {
+	version, err := ParseVersion(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target corpus version: %w", err)
+	}
+
+	var filtered []types.TestCase
+	for _, test := range tests {
+		added, err := AddedAfter(test, version)
+		if err != nil {
+			return nil, err
+		}
+		if !added {
+			filtered = append(filtered, test)
+		}
+	}
+	return filtered, nil
+}
+
 // FilterCompatibleTests filters tests based on implementation capabilities
 func (tl *TestLoader) FilterCompatibleTests(tests []types.TestCase) []types.TestCase {
 	var compatible []types.TestCase
@@ -197,13 +291,41 @@ func (tl *TestLoader) FilterCompatibleTests(tests []types.TestCase) []types.Test
 	return compatible
 }
 
+// ExclusionReason is why a test is incompatible with an implementation
+type ExclusionReason string
+
+const (
+	ReasonTierNotIncluded  ExclusionReason = "tier_not_included"
+	ReasonMissingFunction  ExclusionReason = "missing_function"
+	ReasonMissingFeature   ExclusionReason = "missing_feature"
+	ReasonBehaviorConflict ExclusionReason = "behavior_conflict"
+	ReasonVariantMismatch  ExclusionReason = "variant_mismatch"
+)
+
+// Exclusion explains why a test is incompatible with an implementation
+type Exclusion struct {
+	Reason ExclusionReason
+	Detail string // The tier, function, feature, behavior or variant at fault
+}
+
 // IsTestCompatible checks if a test is compatible with the implementation
 func (tl *TestLoader) IsTestCompatible(test types.TestCase) bool {
+	return tl.CheckCompatibility(test) == nil
+}
+
+// CheckCompatibility returns why a test is incompatible with the implementation, or
+// nil if it is compatible. The first failing requirement is reported.
+func (tl *TestLoader) CheckCompatibility(test types.TestCase) *Exclusion {
+	// Check the test tier is included
+	if !tl.Config.HasTier(config.CCLTier(test.Tier)) {
+		return &Exclusion{Reason: ReasonTierNotIncluded, Detail: test.Tier}
+	}
+
 	// Check function requirements
 	if test.Validation != "" {
 		fn := config.CCLFunction(test.Validation)
 		if !tl.Config.HasFunction(fn) {
-			return false
+			return &Exclusion{Reason: ReasonMissingFunction, Detail: test.Validation}
 		}
 	}
 
@@ -211,7 +333,7 @@ func (tl *TestLoader) IsTestCompatible(test types.TestCase) bool {
 	for _, fnStr := range test.Functions {
 		fn := config.CCLFunction(fnStr)
 		if !tl.Config.HasFunction(fn) {
-			return false
+			return &Exclusion{Reason: ReasonMissingFunction, Detail: fnStr}
 		}
 	}
 
@@ -219,7 +341,7 @@ func (tl *TestLoader) IsTestCompatible(test types.TestCase) bool {
 	for _, featureStr := range test.Features {
 		feature := config.CCLFeature(featureStr)
 		if !tl.Config.HasFeature(feature) {
-			return false
+			return &Exclusion{Reason: ReasonMissingFeature, Detail: featureStr}
 		}
 	}
 
@@ -228,14 +350,16 @@ func (tl *TestLoader) IsTestCompatible(test types.TestCase) bool {
 		for _, behaviorStr := range test.Conflicts.Behaviors {
 			behavior := config.CCLBehavior(behaviorStr)
 			if tl.Config.HasBehavior(behavior) {
-				return false // This test conflicts with our behavior choice
+				// This test conflicts with our behavior choice
+				return &Exclusion{Reason: ReasonBehaviorConflict, Detail: behaviorStr}
 			}
 		}
 
 		for _, variantStr := range test.Conflicts.Variants {
 			variant := config.CCLVariant(variantStr)
 			if tl.Config.HasVariant(variant) {
-				return false // This test conflicts with our variant choice
+				// This test conflicts with our variant choice
+				return &Exclusion{Reason: ReasonVariantMismatch, Detail: variantStr}
 			}
 		}
 	}
@@ -244,7 +368,7 @@ func (tl *TestLoader) IsTestCompatible(test types.TestCase) bool {
 	for _, behaviorStr := range test.Behaviors {
 		behavior := config.CCLBehavior(behaviorStr)
 		if !tl.Config.HasBehavior(behavior) {
-			return false
+			return &Exclusion{Reason: ReasonBehaviorConflict, Detail: behaviorStr}
 		}
 	}
 
@@ -252,11 +376,11 @@ func (tl *TestLoader) IsTestCompatible(test types.TestCase) bool {
 	for _, variantStr := range test.Variants {
 		variant := config.CCLVariant(variantStr)
 		if !tl.Config.HasVariant(variant) {
-			return false
+			return &Exclusion{Reason: ReasonVariantMismatch, Detail: variantStr}
 		}
 	}
 
-	return true
+	return nil
 }
 
 // FilterByTags provides legacy tag-based filtering for backward compatibility
@@ -306,31 +430,48 @@ func (tl *TestLoader) GetTestStatistics(tests []types.TestCase) types.TestStatis
 		TotalAssertions: len(tests), // Each test case is one assertion in flat format
 		ByFunction:      make(map[string]int),
 		ByFeature:       make(map[string]int),
-	}
 
-	compatibleTests := tl.FilterCompatibleTests(tests)
-	stats.CompatibleTests = len(compatibleTests)
-	stats.CompatibleAsserts = len(compatibleTests)
+		CompatibleByFunction: make(map[string]int),
+		CompatibleByFeature:  make(map[string]int),
+		Excluded:             make(map[string][]types.ExcludedTest),
+	}
 
 	for _, test := range tests {
-
-		// Function statistics
-		if test.Validation != "" {
-			stats.ByFunction[test.Validation]++
-		}
-		for _, fn := range test.Functions {
-			stats.ByFunction[fn]++
-		}
-
-		// Feature statistics
-		for _, feature := range test.Features {
-			stats.ByFeature[feature]++
+		countCapabilities(test, stats.ByFunction, stats.ByFeature)
+
+		if exclusion := tl.CheckCompatibility(test); exclusion != nil {
+			reason := string(exclusion.Reason)
+			stats.Excluded[reason] = append(stats.Excluded[reason], types.ExcludedTest{
+				Name:   test.Name,
+				Detail: exclusion.Detail,
+			})
+			continue
 		}
+		stats.CompatibleTests++
+		countCapabilities(test, stats.CompatibleByFunction, stats.CompatibleByFeature)
 	}
+	stats.CompatibleAsserts = stats.CompatibleTests
 
 	return stats
 }
 
+// countCapabilities adds a test to the counts of the functions and features it uses.
+// The validation function is usually also listed in Functions, so each function is
+// counted once per test.
+func countCapabilities(test types.TestCase, byFunction, byFeature map[string]int) {
+	if test.Validation != "" {
+		byFunction[test.Validation]++
+	}
+	for _, fn := range test.Functions {
+		if fn != test.Validation {
+			byFunction[fn]++
+		}
+	}
+	for _, feature := range test.Features {
+		byFeature[feature]++
+	}
+}
+
 // GetCapabilityCoverage analyzes test coverage against implementation capabilities
 func (tl *TestLoader) GetCapabilityCoverage() CapabilityCoverage {
 	allTests, _ := tl.LoadAllTests(LoadOptions{
@@ -405,8 +546,9 @@ type CoverageInfo struct {
 
 // CompactTestFile represents the top-level structure of source test files with $schema support
 type CompactTestFile struct {
-	Schema string        `json:"$schema,omitempty"`
-	Tests  []CompactTest `json:"tests"`
+	Schema  string        `json:"$schema,omitempty"`
+	Version string        `json:"version,omitempty"` // Semantic version of the file's tests
+	Tests   []CompactTest `json:"tests"`
 }
 
 // CompactTest represents a test in compact format (source_tests/ files)
@@ -417,7 +559,10 @@ type CompactTest struct {
 	Features  []string            `json:"features,omitempty"`
 	Behaviors []string            `json:"behaviors,omitempty"`
 	Variants  []string            `json:"variants,omitempty"`
+	Spec      []string            `json:"spec,omitempty"` // Specification sections covered by this test
 	Conflicts *types.ConflictSet  `json:"conflicts,omitempty"`
+	AddedIn   string              `json:"added_in,omitempty"`   // Corpus version that added the test
+	ChangedIn []string            `json:"changed_in,omitempty"` // Corpus versions that changed its expectations
 }
 
 // CompactValidation represents a single validation in compact format
@@ -428,12 +573,18 @@ type CompactValidation struct {
 	Error    bool        `json:"error,omitempty"`
 }
 
-// loadCompactFormat parses compact format and converts to TestCase array
-func (tl *TestLoader) loadCompactFormat(data []byte) ([]types.TestCase, error) {
+// loadCompactFormat parses compact format and converts to TestCase array, returning
+// the file's version with the tests
+func (tl *TestLoader) loadCompactFormat(data []byte) ([]types.TestCase, string, error) {
 	// Parse as object format with $schema and tests array
 	var compactTestFile CompactTestFile
 	if err := json.Unmarshal(data, &compactTestFile); err != nil {
-		return nil, fmt.Errorf("failed to parse compact format JSON: %w", err)
+		return nil, "", fmt.Errorf("failed to parse compact format JSON: %w", err)
+	}
+	if compactTestFile.Version != "" {
+		if _, err := ParseVersion(compactTestFile.Version); err != nil {
+			return nil, "", err
+		}
 	}
 
 	compactTests := compactTestFile.Tests
@@ -476,6 +627,8 @@ func (tl *TestLoader) loadCompactFormat(data []byte) ([]types.TestCase, error) {
 			Variants:  variants,
 			Conflicts: conflicts,
 			Meta:      types.TestMetadata{},
+			AddedIn:   compact.AddedIn,
+			ChangedIn: compact.ChangedIn,
 		}
 
 		// Create ValidationSet from compact tests array
@@ -492,7 +645,7 @@ func (tl *TestLoader) loadCompactFormat(data []byte) ([]types.TestCase, error) {
 				validations.ParseIndented = validationValue
 			case "filter":
 				validations.Filter = validationValue
-			case "combine":
+			case "combine", "compose":
 				validations.Combine = validationValue
 			case "expand_dotted":
 				validations.ExpandDotted = validationValue
@@ -527,7 +680,7 @@ func (tl *TestLoader) loadCompactFormat(data []byte) ([]types.TestCase, error) {
 		testCases = append(testCases, testCase)
 	}
 
-	return testCases, nil
+	return testCases, compactTestFile.Version, nil
 }
 
 // createValidationObject creates a validation object that preserves both expect and args fields
@@ -577,7 +730,7 @@ func (tl *TestLoader) extractExpectedValue(validation string, expected interface
 
 	// Extract the appropriate field based on validation type
 	switch validation {
-	case "parse", "parse_indented", "filter", "compose", "expand_dotted":
+	case "parse", "parse_indented", "filter", "combine", "compose", "expand_dotted":
 		// These expect entries
 		if entries, ok := expectedMap["entries"]; ok {
 			return entries
@@ -587,8 +740,10 @@ func (tl *TestLoader) extractExpectedValue(validation string, expected interface
 		if object, ok := expectedMap["object"]; ok {
 			return object
 		}
-	case "get_string", "get_int", "get_bool", "get_float":
-		// Typed access expects a single value
+	case "get_string", "get_int", "get_bool", "get_float",
+		"round_trip", "canonical_format", "pretty_print",
+		"compose_associative", "identity_left", "identity_right":
+		// Typed access and property checks expect a single value
 		if value, ok := expectedMap["value"]; ok {
 			return value
 		}
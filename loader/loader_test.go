@@ -0,0 +1,86 @@
+package loader
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/catconflang/ccl-test-data/config"
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+func TestLoadAllFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"generated_tests/api_comments.json": {Data: []byte(`{"tests": [
+			{"name": "comment_parse", "inputs": ["/= note"], "validation": "parse", "added_in": "0.4.0"},
+			{"name": "comment_filter", "inputs": ["/= note"], "validation": "filter"}
+		]}`)},
+		"generated_tests/api_typed_access.json": {Data: []byte(`{"tests": [
+			{"name": "port_get_int", "inputs": ["port = 1"], "validation": "get_int", "args": ["port"]}
+		]}`)},
+	}
+
+	testLoader := NewTestLoaderFS(fsys, config.ImplementationConfig{CorpusVersion: "0.3.1"})
+	files, err := testLoader.LoadAllFiles(LoadOptions{Format: FormatFlat, FilterMode: FilterAll})
+	if err != nil {
+		t.Fatalf("LoadAllFiles() error = %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("LoadAllFiles() returned %d files, want 2", len(files))
+	}
+	if files[0].File != "generated_tests/api_comments.json" || len(files[0].Tests) != 1 || files[0].Tests[0].Name != "comment_filter" {
+		t.Errorf("files[0] = %s with %+v, want api_comments.json with only comment_filter", files[0].File, files[0].Tests)
+	}
+	if files[1].File != "generated_tests/api_typed_access.json" || len(files[1].Tests) != 1 {
+		t.Errorf("files[1] = %s with %d tests, want api_typed_access.json with 1", files[1].File, len(files[1].Tests))
+	}
+}
+
+func TestGetTestStatistics(t *testing.T) {
+	tests := []types.TestCase{
+		{Name: "plain_parse", Validation: "parse", Functions: []string{"parse"}},
+		{Name: "comment_parse", Validation: "parse", Functions: []string{"parse"}, Features: []string{"comments"}},
+		{Name: "port_get_int", Validation: "get_int", Functions: []string{"get_int"}},
+		{Name: "unicode_parse", Validation: "parse", Features: []string{"unicode"}},
+		{Name: "tabs_parse", Validation: "parse", Conflicts: &types.ConflictSet{Behaviors: []string{"tabs_as_whitespace"}}},
+		{Name: "sorted_build_hierarchy", Validation: "build_hierarchy", Behaviors: []string{"array_order_lexicographic"}},
+		{Name: "reference_parse", Validation: "parse", Variants: []string{"reference_compliant"}},
+		{Name: "dotted_parse", Validation: "parse", Tier: "experimental"},
+	}
+	testLoader := NewTestLoader(".", config.ImplementationConfig{
+		SupportedFunctions: []config.CCLFunction{config.FunctionParse, config.FunctionBuildHierarchy},
+		SupportedFeatures:  []config.CCLFeature{config.FeatureComments},
+		BehaviorChoices:    []config.CCLBehavior{config.BehaviorTabsAsWhitespace},
+		VariantChoice:      config.VariantProposed,
+	})
+
+	stats := testLoader.GetTestStatistics(tests)
+	if stats.TotalTests != 8 || stats.CompatibleTests != 2 {
+		t.Errorf("TotalTests = %d, CompatibleTests = %d, want 8 and 2", stats.TotalTests, stats.CompatibleTests)
+	}
+	if stats.ByFunction["parse"] != 6 || stats.CompatibleByFunction["parse"] != 2 || stats.CompatibleByFeature["comments"] != 1 {
+		t.Errorf("ByFunction = %v, CompatibleByFunction = %v, CompatibleByFeature = %v",
+			stats.ByFunction, stats.CompatibleByFunction, stats.CompatibleByFeature)
+	}
+
+	want := map[ExclusionReason]string{
+		ReasonMissingFunction:  "port_get_int (get_int)",
+		ReasonMissingFeature:   "unicode_parse (unicode)",
+		ReasonBehaviorConflict: "tabs_parse (tabs_as_whitespace), sorted_build_hierarchy (array_order_lexicographic)",
+		ReasonVariantMismatch:  "reference_parse (reference_compliant)",
+		ReasonTierNotIncluded:  "dotted_parse (experimental)",
+	}
+	if len(stats.Excluded) != len(want) {
+		t.Errorf("Excluded has %d reasons, want %d: %v", len(stats.Excluded), len(want), stats.Excluded)
+	}
+	for reason, wantTests := range want {
+		var got []string
+		for _, test := range stats.Excluded[string(reason)] {
+			got = append(got, test.Name+" ("+test.Detail+")")
+		}
+		if strings.Join(got, ", ") != wantTests {
+			t.Errorf("Excluded[%s] = %v, want %s", reason, got, wantTests)
+		}
+	}
+}
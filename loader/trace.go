@@ -0,0 +1,125 @@
+package loader
+
+import (
+	"fmt"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Trace links a source test to the flat tests generated from it
+type Trace struct {
+	SourceFile string         `json:"source_file"`
+	Source     types.TestCase `json:"source"`
+	Flat       []TracedTest   `json:"flat"` // In generated file order
+}
+
+// TracedTest is a flat test generated from a traced source test, with the metadata
+// the generator changed on the way
+type TracedTest struct {
+	File string         `json:"file"`
+	Test types.TestCase `json:"test"`
+
+	// Behaviors of the source test left out because they do not affect this validation's function
+	DroppedBehaviors []string `json:"dropped_behaviors,omitempty"`
+
+	// Conflicts added to those declared by the source test, such as behaviors
+	// mutually exclusive with the test's own. Nil when none were added.
+	AddedConflicts *types.ConflictSet `json:"added_conflicts,omitempty"`
+}
+
+// Trace finds the source test named name, or the one a flat test named name was
+// generated from, together with every flat test generated from that source test
+func (tl *TestLoader) Trace(name string) (*Trace, error) {
+	all := LoadOptions{FilterMode: FilterAll}
+
+	all.Format = FormatFlat
+	flatFiles, err := tl.LoadAllFiles(all)
+	if err != nil {
+		return nil, err
+	}
+	all.Format = FormatCompact
+	sourceFiles, err := tl.LoadAllFiles(all)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceName := name
+	for _, file := range flatFiles {
+		for _, test := range file.Tests {
+			if test.Name != name {
+				continue
+			}
+			if test.SourceTest == "" {
+				return nil, fmt.Errorf("flat test %s in %s does not record its source test", name, file.File)
+			}
+			sourceName = test.SourceTest
+		}
+	}
+
+	trace := &Trace{}
+	found := false
+	for _, file := range sourceFiles {
+		for _, test := range file.Tests {
+			if test.Name == sourceName {
+				trace.SourceFile, trace.Source, found = file.File, test, true
+			}
+		}
+	}
+	if !found {
+		if sourceName != name {
+			return nil, fmt.Errorf("source test %s of flat test %s not found", sourceName, name)
+		}
+		return nil, fmt.Errorf("no source or flat test named %s", name)
+	}
+
+	for _, file := range flatFiles {
+		for _, test := range file.Tests {
+			if test.SourceTest != sourceName {
+				continue
+			}
+			trace.Flat = append(trace.Flat, TracedTest{
+				File:             file.File,
+				Test:             test,
+				DroppedBehaviors: missing(trace.Source.Behaviors, test.Behaviors),
+				AddedConflicts:   addedConflicts(trace.Source.Conflicts, test.Conflicts),
+			})
+		}
+	}
+	return trace, nil
+}
+
+// addedConflicts returns the conflicts in flat that the source test did not declare
+func addedConflicts(source, flat *types.ConflictSet) *types.ConflictSet {
+	if flat == nil {
+		return nil
+	}
+	if source == nil {
+		source = &types.ConflictSet{}
+	}
+
+	added := &types.ConflictSet{
+		Functions: missing(flat.Functions, source.Functions),
+		Behaviors: missing(flat.Behaviors, source.Behaviors),
+		Variants:  missing(flat.Variants, source.Variants),
+		Features:  missing(flat.Features, source.Features),
+	}
+	if len(added.Functions)+len(added.Behaviors)+len(added.Variants)+len(added.Features) == 0 {
+		return nil
+	}
+	return added
+}
+
+// missing returns the values of from that are not in in, keeping their order
+func missing(from, in []string) []string {
+	present := make(map[string]bool, len(in))
+	for _, value := range in {
+		present[value] = true
+	}
+	var result []string
+	for _, value := range from {
+		if !present[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
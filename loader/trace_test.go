@@ -0,0 +1,53 @@
+package loader
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/catconflang/ccl-test-data/config"
+)
+
+func TestTrace(t *testing.T) {
+	fsys := fstest.MapFS{
+		"source_tests/core/api_typed_access.json": {Data: []byte(`{"tests": [
+			{"name": "bool_yes", "inputs": ["on = yes"], "behaviors": ["boolean_lenient"], "tests": [
+				{"function": "parse", "expect": [{"key": "on", "value": "yes"}]},
+				{"function": "get_bool", "args": ["on"], "expect": true}
+			]}
+		]}`)},
+		"generated_tests/api_typed_access.json": {Data: []byte(`{"tests": [
+			{"name": "bool_yes_parse", "inputs": ["on = yes"], "validation": "parse", "source_test": "bool_yes", "behaviors": []},
+			{"name": "bool_yes_get_bool", "inputs": ["on = yes"], "validation": "get_bool", "source_test": "bool_yes",
+			 "behaviors": ["boolean_lenient"], "conflicts": {"behaviors": ["boolean_strict"]}},
+			{"name": "other_parse", "inputs": ["a = 1"], "validation": "parse", "source_test": "other"}
+		]}`)},
+	}
+	testLoader := NewTestLoaderFS(fsys, config.ImplementationConfig{})
+
+	for _, name := range []string{"bool_yes", "bool_yes_get_bool"} {
+		trace, err := testLoader.Trace(name)
+		if err != nil {
+			t.Fatalf("Trace(%s) error = %v", name, err)
+		}
+		if trace.Source.Name != "bool_yes" || trace.SourceFile != "source_tests/core/api_typed_access.json" {
+			t.Errorf("Trace(%s) source = %s in %s, want bool_yes", name, trace.Source.Name, trace.SourceFile)
+		}
+		if len(trace.Flat) != 2 {
+			t.Fatalf("Trace(%s) found %d flat tests, want 2", name, len(trace.Flat))
+		}
+
+		parse, getBool := trace.Flat[0], trace.Flat[1]
+		if !reflect.DeepEqual(parse.DroppedBehaviors, []string{"boolean_lenient"}) || parse.AddedConflicts != nil {
+			t.Errorf("parse dropped %v and added %+v, want boolean_lenient dropped", parse.DroppedBehaviors, parse.AddedConflicts)
+		}
+		if getBool.DroppedBehaviors != nil || getBool.AddedConflicts == nil ||
+			!reflect.DeepEqual(getBool.AddedConflicts.Behaviors, []string{"boolean_strict"}) {
+			t.Errorf("get_bool dropped %v and added %+v, want boolean_strict added", getBool.DroppedBehaviors, getBool.AddedConflicts)
+		}
+	}
+
+	if _, err := testLoader.Trace("missing"); err == nil {
+		t.Error("Trace(missing) succeeded, want an error")
+	}
+}
@@ -0,0 +1,129 @@
+package loader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/catconflang/ccl-test-data/types"
+)
+
+// Version is a semantic corpus version such as 0.4.0 or 1.0.0-rc.1
+type Version struct {
+	Major, Minor, Patch int
+	Prerelease          string
+}
+
+// ParseVersion parses a semantic version, with or without a leading "v"
+func ParseVersion(s string) (Version, error) {
+	core, prerelease, _ := strings.Cut(strings.TrimPrefix(s, "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q: expected MAJOR.MINOR.PATCH", s)
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (len(part) > 1 && part[0] == '0') {
+			return Version{}, fmt.Errorf("invalid version %q: %q is not a version number", s, part)
+		}
+		numbers[i] = n
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Prerelease: prerelease}, nil
+}
+
+// String formats the version without a leading "v"
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as, or newer than other.
+// A prerelease is older than its release; prereleases compare as strings.
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+	return strings.Compare(v.Prerelease, other.Prerelease)
+}
+
+// NextMinor returns the first release of the minor version after v, the version
+// new tests are added in
+func (v Version) NextMinor() Version {
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// CorpusChanges lists the tests added or changed after a corpus version
+type CorpusChanges struct {
+	Since   Version
+	Added   []types.TestCase // added_in is newer than Since
+	Changed []types.TestCase // Present in Since, with a changed_in version newer than Since
+}
+
+// ChangesSince reports the tests an implementation validated against since has not
+// seen, or has seen with different expectations. Tests without added_in are part of
+// every version.
+func ChangesSince(tests []types.TestCase, since string) (*CorpusChanges, error) {
+	sinceVersion, err := ParseVersion(since)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &CorpusChanges{Since: sinceVersion}
+	for _, test := range tests {
+		added, err := AddedAfter(test, sinceVersion)
+		if err != nil {
+			return nil, err
+		}
+		if added {
+			changes.Added = append(changes.Added, test)
+			continue
+		}
+		for _, changedIn := range test.ChangedIn {
+			version, err := ParseVersion(changedIn)
+			if err != nil {
+				return nil, fmt.Errorf("test %s: %w", test.Name, err)
+			}
+			if version.Compare(sinceVersion) > 0 {
+				changes.Changed = append(changes.Changed, test)
+				break
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// AddedAfter reports whether a test was added to the corpus after version
+func AddedAfter(test types.TestCase, version Version) (bool, error) {
+	if test.AddedIn == "" {
+		return false, nil
+	}
+	added, err := ParseVersion(test.AddedIn)
+	if err != nil {
+		return false, fmt.Errorf("test %s: %w", test.Name, err)
+	}
+	return added.Compare(version) > 0, nil
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,37 @@
+package loader
+
+import "testing"
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"0.3.1", "0.4.0", -1},
+		{"v0.4.0", "0.4.0", 0},
+		{"1.0.0", "0.10.0", 1},
+		{"0.10.0", "0.9.9", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseVersion(tt.a)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q) error = %v", tt.a, err)
+		}
+		b, err := ParseVersion(tt.b)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q) error = %v", tt.b, err)
+		}
+		if got := a.Compare(b); got != tt.expected {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", "1.0", "1.0.x", "01.0.0", "1.0.0.0"} {
+		if _, err := ParseVersion(invalid); err == nil {
+			t.Errorf("ParseVersion(%q) succeeded, want error", invalid)
+		}
+	}
+}
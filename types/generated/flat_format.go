@@ -12,15 +12,24 @@ type GeneratedFormatSimpleJson struct {
 
 	// Tests corresponds to the JSON schema field "tests".
 	Tests []GeneratedFormatSimpleJsonTestsElem `json:"tests" yaml:"tests" mapstructure:"tests"`
+
+	// Version corresponds to the JSON schema field "version".
+	Version *string `json:"version,omitempty" yaml:"version,omitempty" mapstructure:"version,omitempty"`
 }
 
 type GeneratedFormatSimpleJsonTestsElem struct {
+	// AddedIn corresponds to the JSON schema field "added_in".
+	AddedIn *string `json:"added_in,omitempty" yaml:"added_in,omitempty" mapstructure:"added_in,omitempty"`
+
 	// Args corresponds to the JSON schema field "args".
 	Args []string `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
 
 	// Behaviors corresponds to the JSON schema field "behaviors".
 	Behaviors []GeneratedFormatSimpleJsonTestsElemBehaviorsElem `json:"behaviors" yaml:"behaviors" mapstructure:"behaviors"`
 
+	// ChangedIn corresponds to the JSON schema field "changed_in".
+	ChangedIn []string `json:"changed_in,omitempty" yaml:"changed_in,omitempty" mapstructure:"changed_in,omitempty"`
+
 	// Conflicts corresponds to the JSON schema field "conflicts".
 	Conflicts *GeneratedFormatSimpleJsonTestsElemConflicts `json:"conflicts,omitempty" yaml:"conflicts,omitempty" mapstructure:"conflicts,omitempty"`
 
@@ -51,6 +60,9 @@ type GeneratedFormatSimpleJsonTestsElem struct {
 	// SourceTest corresponds to the JSON schema field "source_test".
 	SourceTest *string `json:"source_test,omitempty" yaml:"source_test,omitempty" mapstructure:"source_test,omitempty"`
 
+	// Tier corresponds to the JSON schema field "tier".
+	Tier *string `json:"tier,omitempty" yaml:"tier,omitempty" mapstructure:"tier,omitempty"`
+
 	// Validation corresponds to the JSON schema field "validation".
 	Validation GeneratedFormatSimpleJsonTestsElemValidation `json:"validation" yaml:"validation" mapstructure:"validation"`
 
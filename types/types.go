@@ -37,6 +37,13 @@ type TestCase struct {
 
 	// Flat format traceability
 	SourceTest string `json:"source_test,omitempty"`
+
+	// Source directory tier, e.g. "core" or "experimental"
+	Tier string `json:"tier,omitempty"`
+
+	// Corpus versions that added the test and changed its expectations
+	AddedIn   string   `json:"added_in,omitempty"`
+	ChangedIn []string `json:"changed_in,omitempty"`
 }
 
 // ConflictSet provides structured conflict resolution
@@ -47,6 +54,29 @@ type ConflictSet struct {
 	Features  []string `json:"features"`
 }
 
+// Tags flattens the conflict set into kind:value tags, e.g. behavior:boolean_strict.
+// A nil set has no tags.
+func (c *ConflictSet) Tags() []string {
+	if c == nil {
+		return nil
+	}
+	var tags []string
+	for _, group := range []struct {
+		kind   string
+		values []string
+	}{
+		{"function", c.Functions},
+		{"behavior", c.Behaviors},
+		{"variant", c.Variants},
+		{"feature", c.Features},
+	} {
+		for _, value := range group.values {
+			tags = append(tags, group.kind+":"+value)
+		}
+	}
+	return tags
+}
+
 // ValidationSet contains all possible validations (source format)
 type ValidationSet struct {
 	Parse              interface{} `json:"parse,omitempty"`
@@ -86,9 +116,23 @@ type TestStatistics struct {
 	ByFunction map[string]int
 	ByFeature  map[string]int
 
+	// Function and feature counts restricted to compatible tests
+	CompatibleByFunction map[string]int
+	CompatibleByFeature  map[string]int
+
+	// Incompatible tests, keyed by exclusion reason (missing_function, missing_feature,
+	// behavior_conflict, variant_mismatch or tier_not_included)
+	Excluded map[string][]ExcludedTest
+
 	ConflictingSets []ConflictSummary
 }
 
+// ExcludedTest is a test left out of an implementation's compatible tests
+type ExcludedTest struct {
+	Name   string `json:"name"`
+	Detail string `json:"detail"` // The tier, function, feature, behavior or variant at fault
+}
+
 // ConflictSummary provides analysis of conflicting test sets
 type ConflictSummary struct {
 	ConflictType  string // "behavior", "variant", "feature"